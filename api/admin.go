@@ -0,0 +1,225 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/university-service/internal/models"
+	"github.com/university-service/internal/repository"
+	"github.com/university-service/internal/service"
+)
+
+// AdminHandler agrupa as operações administrativas, expostas sob /admin.
+type AdminHandler struct {
+	repo      repository.UniversityStore
+	events    *eventWriter
+	retention time.Duration
+	publisher service.EventPublisher
+	// nil se o publisher não guarda dead letters
+	deadLetters service.DeadLetterQueue
+	reindex     reindexJob
+}
+
+func NewAdminHandler(repo repository.UniversityStore, publisher service.EventPublisher, outbox *repository.OutboxRepository, retention time.Duration) *AdminHandler {
+	deadLetters, _ := publisher.(service.DeadLetterQueue)
+	return &AdminHandler{
+		repo:        repo,
+		events:      &eventWriter{repo: repo, publisher: publisher, outbox: outbox},
+		retention:   retention,
+		publisher:   publisher,
+		deadLetters: deadLetters,
+	}
+}
+
+// PurgeUniversities remove definitivamente as universidades excluídas há mais
+// tempo que o período de retenção. O parâmetro retention (por exemplo "24h")
+// sobrescreve o valor configurado.
+func (h *AdminHandler) PurgeUniversities(c *gin.Context) {
+	retention := h.retention
+	if value := c.Query("retention"); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil || d < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid retention: " + value})
+			return
+		}
+		retention = d
+	}
+
+	var purged []models.University
+	err := h.events.persist(c.Request.Context(), func(ctx context.Context) ([]*models.UniversityEvent, error) {
+		var err error
+		purged, err = h.repo.Purge(ctx, time.Now().Add(-retention))
+		if err != nil {
+			return nil, err
+		}
+
+		events := make([]*models.UniversityEvent, 0, len(purged))
+		for i := range purged {
+			events = append(events, newEvent(ctx, models.EventUniversityPurged, &purged[i]))
+		}
+		return events, nil
+	})
+	if err != nil {
+		respondWriteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  http.StatusOK,
+		"message": "Universities purged successfully",
+		"purged":  len(purged),
+	})
+}
+
+// reindexJob guarda o andamento do último reindex iniciado pela API. Só um
+// reindex roda por vez.
+type reindexJob struct {
+	mu     sync.Mutex
+	status reindexStatus
+}
+
+type reindexStatus struct {
+	Running    bool       `json:"running"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Published  int        `json:"published"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// start marca o início de um reindex, retornando false se já houver um em
+// andamento.
+func (j *reindexJob) start() (reindexStatus, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.status.Running {
+		return j.status, false
+	}
+	j.status = reindexStatus{Running: true, StartedAt: time.Now()}
+	return j.status, true
+}
+
+func (j *reindexJob) finish(published int, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	now := time.Now()
+	j.status.Running = false
+	j.status.FinishedAt = &now
+	j.status.Published = published
+	if err != nil {
+		j.status.Error = "reindex failed"
+	}
+}
+
+func (j *reindexJob) current() reindexStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.status
+}
+
+// ReindexUniversities inicia em segundo plano a publicação de um evento
+// university_snapshot para cada universidade que atende aos filtros name,
+// email_domain, website e include_deleted, no máximo rate por segundo e até
+// limit universidades. Responde 202 assim que o reindex começa; o andamento é
+// consultado em ReindexStatus.
+func (h *AdminHandler) ReindexUniversities(c *gin.Context) {
+	filter := repository.ListOptions{
+		Name:        c.Query("name"),
+		EmailDomain: c.Query("email_domain"),
+		Website:     c.Query("website"),
+	}
+
+	includeDeleted, err := queryBool(c, "include_deleted")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter.IncludeDeleted = includeDeleted
+
+	limit, err := queryInt(c, "limit", 0)
+	if err != nil || limit < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must not be negative"})
+		return
+	}
+	filter.Limit = limit
+
+	rate, err := queryInt(c, "rate", 0)
+	if err != nil || rate < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "rate must not be negative"})
+		return
+	}
+	if rate > service.MaxReindexRate {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("rate must be at most %d", service.MaxReindexRate)})
+		return
+	}
+
+	status, ok := h.reindex.start()
+	if !ok {
+		c.JSON(http.StatusConflict, gin.H{"error": "a reindex is already running", "reindex": status})
+		return
+	}
+
+	// O reindex continua depois da resposta, então não usa o contexto da
+	// requisição; só o correlation_id é mantido nos eventos
+	ctx := service.WithCorrelationID(context.Background(), service.CorrelationID(c.Request.Context()))
+	go func() {
+		published, err := service.Reindex(ctx, h.repo, h.publisher, service.ReindexOptions{
+			Filter: filter,
+			Rate:   int(rate),
+		})
+		if err != nil {
+			log.Printf("reindex failed after %d snapshot events: %v", published, err)
+		} else {
+			log.Printf("reindex: %d snapshot events published", published)
+		}
+		h.reindex.finish(published, err)
+	}()
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"status":  http.StatusAccepted,
+		"message": "Reindex started",
+		"reindex": status,
+	})
+}
+
+// ReindexStatus informa o andamento do último reindex iniciado pela API.
+func (h *AdminHandler) ReindexStatus(c *gin.Context) {
+	status := h.reindex.current()
+	if status.StartedAt.IsZero() {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no reindex has been started"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  http.StatusOK,
+		"reindex": status,
+	})
+}
+
+// backuper é implementado pelos armazenamentos em arquivo, como o BoltStore.
+type backuper interface {
+	Backup(w io.Writer) (int64, error)
+}
+
+// BackupStorage devolve uma cópia consistente do arquivo do armazenamento,
+// gerada sem parar o serviço. Só está disponível no backend bolt.
+func (h *AdminHandler) BackupStorage(c *gin.Context) {
+	store, ok := h.repo.(backuper)
+	if !ok {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "backup is not supported by the storage backend"})
+		return
+	}
+
+	filename := "universities-" + time.Now().UTC().Format("20060102T150405Z") + ".db"
+	c.Header("Content-Type", "application/octet-stream")
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Status(http.StatusOK)
+	if _, err := store.Backup(c.Writer); err != nil {
+		// O status já foi enviado; resta registrar o erro
+		c.Error(err)
+	}
+}
@@ -0,0 +1,258 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/university-service/internal/models"
+	"github.com/university-service/internal/repository"
+	"github.com/university-service/internal/service"
+)
+
+func setupAdminRouter(t *testing.T, publisher service.EventPublisher) (*gin.Engine, *repository.MemoryStore) {
+	gin.SetMode(gin.TestMode)
+
+	store := repository.NewMemoryStore()
+	admin := NewAdminHandler(store, publisher, nil, time.Hour)
+	r := gin.New()
+	r.POST("/admin/universities/purge", admin.PurgeUniversities)
+	r.POST("/admin/universities/reindex", admin.ReindexUniversities)
+	r.GET("/admin/universities/reindex", admin.ReindexStatus)
+	r.GET("/admin/events/dead-letters", admin.ListDeadLetters)
+	r.POST("/admin/events/dead-letters/replay", admin.ReplayDeadLetters)
+	return r, store
+}
+
+func TestAdminHandler_PurgeUniversities(t *testing.T) {
+	bus := service.NewMemoryBus()
+	events := &recordedEvents{}
+	t.Cleanup(bus.Subscribe(events.handle))
+	router, store := setupAdminRouter(t, bus)
+
+	ctx := context.Background()
+	deleted := newTestUniversity("Excluída")
+	active := newTestUniversity("Ativa")
+	require.NoError(t, store.Create(ctx, deleted))
+	require.NoError(t, store.Create(ctx, active))
+	require.NoError(t, store.Delete(ctx, deleted))
+
+	purge := func(query string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/admin/universities/purge"+query, nil))
+		var response map[string]interface{}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return w, response
+	}
+
+	t.Run("Invalid Retention", func(t *testing.T) {
+		for _, query := range []string{"?retention=soon", "?retention=-1h"} {
+			w, _ := purge(query)
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+	})
+
+	t.Run("Within Retention", func(t *testing.T) {
+		// A retenção configurada é de uma hora
+		w, response := purge("")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, float64(0), response["purged"])
+		_, err := store.GetByIDIncludingDeleted(ctx, deleted.ID.Hex())
+		assert.NoError(t, err)
+	})
+
+	t.Run("Past Retention", func(t *testing.T) {
+		w, response := purge("?retention=0s")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "Universities purged successfully", response["message"])
+		assert.Equal(t, float64(1), response["purged"])
+
+		_, err := store.GetByIDIncludingDeleted(ctx, deleted.ID.Hex())
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+		_, err = store.GetByID(ctx, active.ID.Hex())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{models.EventUniversityPurged}, events.types())
+	})
+}
+
+func TestAdminHandler_ReindexUniversities(t *testing.T) {
+	bus := service.NewMemoryBus()
+	events := &recordedEvents{}
+	t.Cleanup(bus.Subscribe(events.handle))
+	router, store := setupAdminRouter(t, bus)
+
+	for _, name := range []string{"Primeira", "Segunda"} {
+		require.NoError(t, store.Create(context.Background(), newTestUniversity(name)))
+	}
+
+	t.Run("No Reindex Yet", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/admin/universities/reindex", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Invalid Parameters", func(t *testing.T) {
+		for _, query := range []string{"rate=-1", "rate=1000000001", "limit=-1", "include_deleted=maybe"} {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("POST", "/admin/universities/reindex?"+query, nil))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+	})
+
+	t.Run("Runs In Background", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/admin/universities/reindex?rate=10000", nil))
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+
+		var status struct {
+			Reindex reindexStatus `json:"reindex"`
+		}
+		require.Eventually(t, func() bool {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", "/admin/universities/reindex", nil))
+			require.Equal(t, http.StatusOK, w.Code)
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+			return !status.Reindex.Running
+		}, 5*time.Second, 10*time.Millisecond)
+
+		assert.Equal(t, 2, status.Reindex.Published)
+		assert.Empty(t, status.Reindex.Error)
+		assert.Equal(t, []string{models.EventUniversitySnapshot, models.EventUniversitySnapshot}, events.types())
+	})
+}
+
+// fakeDeadLetters é um publisher que guarda dead letters em memória.
+type fakeDeadLetters struct {
+	*service.MemoryBus
+	letters []models.DeadLetter
+	// Erro retornado pelas operações de dead letters
+	err      error
+	replayed int
+}
+
+func (f *fakeDeadLetters) ListDeadLetters(ctx context.Context, limit int) ([]models.DeadLetter, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if limit > len(f.letters) {
+		limit = len(f.letters)
+	}
+	return f.letters[:limit], nil
+}
+
+func (f *fakeDeadLetters) ReplayDeadLetters(ctx context.Context, limit int) (int, error) {
+	if f.err != nil {
+		return f.replayed, f.err
+	}
+	replayed := min(limit, len(f.letters))
+	f.letters = f.letters[replayed:]
+	return replayed, nil
+}
+
+func TestAdminHandler_DeadLetters(t *testing.T) {
+	newLetters := func() []models.DeadLetter {
+		uni := newTestUniversity("Test University")
+		letters := make([]models.DeadLetter, 0, 3)
+		for i := 0; i < 3; i++ {
+			letters = append(letters, models.DeadLetter{
+				Event:    models.NewUniversityEvent(models.EventUniversityUpdated, uni),
+				Error:    "broker down",
+				Attempts: 5,
+				FailedAt: time.Now(),
+				Source:   "publish",
+			})
+		}
+		return letters
+	}
+
+	t.Run("Not Configured", func(t *testing.T) {
+		router, _ := setupAdminRouter(t, service.NewMemoryBus())
+
+		for _, req := range []*http.Request{
+			httptest.NewRequest("GET", "/admin/events/dead-letters", nil),
+			httptest.NewRequest("POST", "/admin/events/dead-letters/replay", nil),
+		} {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusNotFound, w.Code, req.URL.Path)
+			assert.Contains(t, w.Body.String(), "dead letters are not configured")
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		router, _ := setupAdminRouter(t, &fakeDeadLetters{MemoryBus: service.NewMemoryBus(), letters: newLetters()})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/admin/events/dead-letters?limit=2", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.DeadLetterListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Data, 2)
+		assert.Equal(t, "broker down", response.Data[0].Error)
+		assert.Equal(t, models.EventUniversityUpdated, response.Data[0].Event.Type)
+	})
+
+	t.Run("Replay", func(t *testing.T) {
+		publisher := &fakeDeadLetters{MemoryBus: service.NewMemoryBus(), letters: newLetters()}
+		router, _ := setupAdminRouter(t, publisher)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/admin/events/dead-letters/replay?limit=2", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, float64(2), response["replayed"])
+		assert.Len(t, publisher.letters, 1)
+	})
+
+	t.Run("Invalid Limit", func(t *testing.T) {
+		router, _ := setupAdminRouter(t, &fakeDeadLetters{MemoryBus: service.NewMemoryBus()})
+
+		for _, query := range []string{"?limit=0", "?limit=1001", "?limit=all"} {
+			for _, req := range []*http.Request{
+				httptest.NewRequest("GET", "/admin/events/dead-letters"+query, nil),
+				httptest.NewRequest("POST", "/admin/events/dead-letters/replay"+query, nil),
+			} {
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+
+				assert.Equal(t, http.StatusBadRequest, w.Code, req.URL.String())
+			}
+		}
+	})
+
+	t.Run("Failures Do Not Leak Errors", func(t *testing.T) {
+		publisher := &fakeDeadLetters{
+			MemoryBus: service.NewMemoryBus(),
+			err:       errors.New("kafka: broker 10.0.0.5:9092 unreachable"),
+			replayed:  1,
+		}
+		router, _ := setupAdminRouter(t, publisher)
+
+		for _, req := range []*http.Request{
+			httptest.NewRequest("GET", "/admin/events/dead-letters", nil),
+			httptest.NewRequest("POST", "/admin/events/dead-letters/replay", nil),
+		} {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusInternalServerError, w.Code, req.URL.Path)
+			assert.JSONEq(t, `{"error": "internal server error"}`, w.Body.String())
+		}
+	})
+}
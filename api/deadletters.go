@@ -0,0 +1,90 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/university-service/internal/models"
+	"github.com/university-service/internal/service"
+)
+
+const (
+	defaultDeadLetterLimit int64 = 100
+	maxDeadLetterLimit     int64 = 1000
+)
+
+// ListDeadLetters lista os eventos que esgotaram as tentativas de publicação
+// e ainda não foram reenviados.
+func (h *AdminHandler) ListDeadLetters(c *gin.Context) {
+	if h.deadLetters == nil {
+		respondDeadLetterError(c, service.ErrDeadLettersDisabled)
+		return
+	}
+	limit, ok := deadLetterLimit(c)
+	if !ok {
+		return
+	}
+
+	letters, err := h.deadLetters.ListDeadLetters(c.Request.Context(), int(limit))
+	if err != nil {
+		respondDeadLetterError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.DeadLetterListResponse{
+		Status:  http.StatusOK,
+		Message: "Dead letters retrieved successfully",
+		Data:    letters,
+	})
+}
+
+// ReplayDeadLetters publica de novo no tópico de eventos até limit dead
+// letters, das mais antigas para as mais novas.
+func (h *AdminHandler) ReplayDeadLetters(c *gin.Context) {
+	if h.deadLetters == nil {
+		respondDeadLetterError(c, service.ErrDeadLettersDisabled)
+		return
+	}
+	limit, ok := deadLetterLimit(c)
+	if !ok {
+		return
+	}
+
+	replayed, err := h.deadLetters.ReplayDeadLetters(c.Request.Context(), int(limit))
+	if err != nil {
+		// Parte das dead letters pode ter sido reenviada antes da falha
+		respondDeadLetterError(c, fmt.Errorf("replay stopped after %d dead letters: %w", replayed, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":   http.StatusOK,
+		"message":  "Dead letters replayed successfully",
+		"replayed": replayed,
+	})
+}
+
+func deadLetterLimit(c *gin.Context) (int64, bool) {
+	limit, err := queryInt(c, "limit", defaultDeadLetterLimit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return 0, false
+	}
+	if limit < 1 || limit > maxDeadLetterLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("limit must be between 1 and %d", maxDeadLetterLimit)})
+		return 0, false
+	}
+	return limit, true
+}
+
+// respondDeadLetterError responde 404 se as dead letters não estiverem
+// configuradas; os demais erros seguem respondError.
+func respondDeadLetterError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrDeadLettersDisabled) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "dead letters are not configured"})
+		return
+	}
+	respondError(c, err, "dead letters not found")
+}
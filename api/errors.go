@@ -0,0 +1,44 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/university-service/internal/repository"
+)
+
+// respondError traduz os erros do armazenamento para a resposta HTTP. A
+// mensagem original nunca é enviada ao cliente; erros sem tradução viram 500
+// e ficam registrados no log da requisição. notFound é a mensagem usada quando
+// o registro não existe.
+func respondError(c *gin.Context, err error, notFound string) {
+	switch {
+	case errors.Is(err, repository.ErrInvalidID):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid university id"})
+	case errors.Is(err, repository.ErrInvalidCursor):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+	case errors.Is(err, repository.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": notFound})
+	case errors.Is(err, repository.ErrVersionConflict):
+		respondVersionConflict(c)
+	case errors.Is(err, repository.ErrDuplicate):
+		c.JSON(http.StatusConflict, gin.H{"error": "university already exists"})
+	case errors.Is(err, repository.ErrConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": "university was modified concurrently"})
+	case errors.Is(err, repository.ErrUnavailable):
+		c.Error(err)
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "storage unavailable, try again later"})
+	case errors.Is(err, errPublish):
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to publish event"})
+	default:
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+// respondWriteError traduz os erros de persist para a resposta HTTP.
+func respondWriteError(c *gin.Context, err error) {
+	respondError(c, err, "university not found")
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/university-service/internal/models"
+)
+
+// etag deriva a ETag da universidade a partir do seu número de versão.
+func etag(university *models.University) string {
+	return `"` + strconv.FormatInt(university.Version, 10) + `"`
+}
+
+func setETag(c *gin.Context, university *models.University) {
+	c.Header("ETag", etag(university))
+}
+
+// checkIfMatch verifica o cabeçalho If-Match (comparação forte) e responde 412
+// quando a versão enviada pelo cliente não é a atual. Retorna false se a
+// requisição não deve prosseguir.
+func checkIfMatch(c *gin.Context, university *models.University) bool {
+	header := c.GetHeader("If-Match")
+	if header == "" || matchesETag(header, etag(university), false) {
+		return true
+	}
+
+	c.JSON(http.StatusPreconditionFailed, gin.H{"error": "precondition failed: university has been modified"})
+	return false
+}
+
+// notModified verifica o cabeçalho If-None-Match (comparação fraca) e responde
+// 304 quando o cliente já possui a versão atual.
+func notModified(c *gin.Context, university *models.University) bool {
+	header := c.GetHeader("If-None-Match")
+	if header == "" || !matchesETag(header, etag(university), true) {
+		return false
+	}
+
+	c.Status(http.StatusNotModified)
+	return true
+}
+
+func matchesETag(header, current string, weak bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if weak {
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+		if candidate == current {
+			return true
+		}
+	}
+	return false
+}
+
+func respondVersionConflict(c *gin.Context) {
+	c.JSON(http.StatusPreconditionFailed, gin.H{"error": "precondition failed: university was modified concurrently"})
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_ConditionalGet(t *testing.T) {
+	router, store, _ := setupTestRouter(t)
+
+	uni := newTestUniversity("Test University")
+	require.NoError(t, store.Create(context.Background(), uni))
+
+	tests := []struct {
+		name   string
+		header string
+		status int
+	}{
+		{"Current Version", `"1"`, http.StatusNotModified},
+		{"Weak Comparison", `W/"1"`, http.StatusNotModified},
+		{"One Of Many", `"7", "1"`, http.StatusNotModified},
+		{"Wildcard", `*`, http.StatusNotModified},
+		{"Stale Version", `"0"`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/universities/"+uni.ID.Hex(), nil)
+			req.Header.Set("If-None-Match", tt.header)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+			if tt.status == http.StatusNotModified {
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_IfMatch(t *testing.T) {
+	router, store, events := setupTestRouter(t)
+
+	uni := newTestUniversity("Test University")
+	require.NoError(t, store.Create(context.Background(), uni))
+	body, _ := json.Marshal(newTestUniversity("Updated University"))
+
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		body        []byte
+	}{
+		{"PUT", "PUT", "application/json", body},
+		{"PATCH", "PATCH", mergePatchContentType, []byte(`{"name": "Patched University"}`)},
+		{"DELETE", "DELETE", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/universities/"+uni.ID.Hex(), bytes.NewBuffer(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			req.Header.Set("If-Match", `"2"`)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+			assert.Contains(t, w.Body.String(), "precondition failed")
+		})
+	}
+
+	// Nenhuma das requisições alterou o registro
+	saved, err := store.GetByID(context.Background(), uni.ID.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), saved.Version)
+	assert.Equal(t, "Test University", saved.Name)
+	assert.Empty(t, events.types())
+
+	t.Run("Weak ETag Is Rejected", func(t *testing.T) {
+		req := httptest.NewRequest("DELETE", "/universities/"+uni.ID.Hex(), nil)
+		req.Header.Set("If-Match", `W/"1"`)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	})
+
+	t.Run("Current Version", func(t *testing.T) {
+		req := httptest.NewRequest("DELETE", "/universities/"+uni.ID.Hex(), nil)
+		req.Header.Set("If-Match", `"1"`)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
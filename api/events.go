@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/university-service/internal/models"
+	"github.com/university-service/internal/repository"
+	"github.com/university-service/internal/service"
+)
+
+var errPublish = errors.New("failed to publish event")
+
+// writeFunc executa uma gravação no repositório e retorna os eventos que ela
+// gerou.
+type writeFunc func(ctx context.Context) ([]*models.UniversityEvent, error)
+
+// eventWriter garante que uma gravação e seus eventos não divirjam. Com o
+// outbox, a gravação e os eventos são confirmados na mesma transação e o
+// OutboxRelay faz a publicação; sem ele, os eventos são publicados logo após a
+// gravação.
+type eventWriter struct {
+	repo      repository.UniversityStore
+	publisher service.EventPublisher
+	outbox    *repository.OutboxRepository
+}
+
+func (w *eventWriter) persist(ctx context.Context, write writeFunc) error {
+	if w.outbox == nil {
+		events, err := write(ctx)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if err := w.publisher.PublishEvent(ctx, event); err != nil {
+				return fmt.Errorf("%w: %v", errPublish, err)
+			}
+		}
+		return nil
+	}
+
+	return w.repo.WithTransaction(ctx, func(ctx context.Context) error {
+		events, err := write(ctx)
+		if err != nil {
+			return err
+		}
+		return w.outbox.Enqueue(ctx, events...)
+	})
+}
+
+// newEvent cria o evento associando a ele o ID da requisição em andamento.
+func newEvent(ctx context.Context, eventType string, university *models.University) *models.UniversityEvent {
+	event := models.NewUniversityEvent(eventType, university)
+	event.CorrelationID = service.CorrelationID(ctx)
+	return event
+}
@@ -1,7 +1,10 @@
 package api
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/university-service/internal/models"
@@ -10,14 +13,16 @@ import (
 )
 
 type Handler struct {
-	repo  *repository.UniversityRepository
-	kafka *service.KafkaService
+	repo   repository.UniversityStore
+	events *eventWriter
 }
 
-func NewHandler(repo *repository.UniversityRepository, kafka *service.KafkaService) *Handler {
+// NewHandler cria o handler da API. Se outbox for nil, os eventos são
+// publicados diretamente no Kafka após cada gravação.
+func NewHandler(repo repository.UniversityStore, publisher service.EventPublisher, outbox *repository.OutboxRepository) *Handler {
 	return &Handler{
-		repo:  repo,
-		kafka: kafka,
+		repo:   repo,
+		events: &eventWriter{repo: repo, publisher: publisher, outbox: outbox},
 	}
 }
 
@@ -28,17 +33,20 @@ func (h *Handler) CreateUniversity(c *gin.Context) {
 		return
 	}
 
-	if err := h.repo.Create(c.Request.Context(), &university); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-
-	// Publicar evento no Kafka
-	if err := h.kafka.PublishUniversityEvent(c.Request.Context(), "university_created", &university); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to publish event"})
+	// A exclusão só é feita pelo DELETE; um registro novo nasce ativo
+	university.DeletedAt = nil
+	err := h.events.persist(c.Request.Context(), func(ctx context.Context) ([]*models.UniversityEvent, error) {
+		if err := h.repo.Create(ctx, &university); err != nil {
+			return nil, err
+		}
+		return []*models.UniversityEvent{newEvent(ctx, models.EventUniversityCreated, &university)}, nil
+	})
+	if err != nil {
+		respondWriteError(c, err)
 		return
 	}
 
+	setETag(c, &university)
 	c.JSON(http.StatusCreated, models.UniversityResponse{
 		Status:  http.StatusCreated,
 		Message: "University created successfully",
@@ -48,9 +56,25 @@ func (h *Handler) CreateUniversity(c *gin.Context) {
 
 func (h *Handler) GetUniversity(c *gin.Context) {
 	id := c.Param("id")
-	university, err := h.repo.GetByID(c.Request.Context(), id)
+	includeDeleted, err := queryBool(c, "include_deleted")
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "university not found"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var university *models.University
+	if includeDeleted {
+		university, err = h.repo.GetByIDIncludingDeleted(c.Request.Context(), id)
+	} else {
+		university, err = h.repo.GetByID(c.Request.Context(), id)
+	}
+	if err != nil {
+		respondError(c, err, "university not found")
+		return
+	}
+
+	setETag(c, university)
+	if notModified(c, university) {
 		return
 	}
 
@@ -62,16 +86,112 @@ func (h *Handler) GetUniversity(c *gin.Context) {
 }
 
 func (h *Handler) ListUniversities(c *gin.Context) {
-	universities, err := h.repo.GetAll(c.Request.Context())
+	opts, err := parseListOptions(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"status":  http.StatusOK,
-		"message": "Universities retrieved successfully",
-		"data":    universities,
+	if cursor, ok := c.GetQuery("cursor"); ok {
+		h.listUniversitiesByCursor(c, opts, cursor)
+		return
+	}
+
+	universities, total, err := h.repo.List(c.Request.Context(), opts)
+	if err != nil {
+		respondError(c, err, "no universities found")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.UniversityListResponse{
+		Status:     http.StatusOK,
+		Message:    "Universities retrieved successfully",
+		Data:       universities,
+		Pagination: buildPagination(opts, total),
+		Links:      buildLinks(c, opts, total),
+	})
+}
+
+// listUniversitiesByCursor atende GET /universities?cursor=..., usado para
+// varrer a coleção inteira sem pular nem repetir registros.
+func (h *Handler) listUniversitiesByCursor(c *gin.Context, opts repository.ListOptions, cursor string) {
+	if len(opts.Sort) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "sort is not supported with cursor pagination"})
+		return
+	}
+
+	universities, nextCursor, err := h.repo.ListByCursor(c.Request.Context(), opts, cursor)
+	if err != nil {
+		respondError(c, err, "no universities found")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.UniversityListResponse{
+		Status:     http.StatusOK,
+		Message:    "Universities retrieved successfully",
+		Data:       universities,
+		Links:      buildCursorLinks(c, cursor, nextCursor),
+		NextCursor: nextCursor,
+	})
+}
+
+func (h *Handler) SearchUniversities(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "query parameter q is required"})
+		return
+	}
+
+	limit, err := queryInt(c, "limit", repository.DefaultSearchLimit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if limit < 1 || limit > repository.MaxPageSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("limit must be between 1 and %d", repository.MaxPageSize)})
+		return
+	}
+
+	hits, err := h.repo.Search(c.Request.Context(), query, limit)
+	if err != nil {
+		respondError(c, err, "no search results found")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SearchResponse{
+		Status:  http.StatusOK,
+		Message: "Universities retrieved successfully",
+		Data:    hits,
+	})
+}
+
+func (h *Handler) AutocompleteUniversities(c *gin.Context) {
+	prefix := strings.TrimSpace(c.Query("prefix"))
+	if prefix == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "query parameter prefix is required"})
+		return
+	}
+
+	limit, err := queryInt(c, "limit", repository.DefaultAutocompleteLimit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if limit < 1 || limit > repository.MaxAutocompleteLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("limit must be between 1 and %d", repository.MaxAutocompleteLimit)})
+		return
+	}
+
+	suggestions, err := h.repo.Autocomplete(c.Request.Context(), prefix, limit)
+	if err != nil {
+		respondError(c, err, "no suggestions found")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuggestionResponse{
+		Status:  http.StatusOK,
+		Message: "Suggestions retrieved successfully",
+		Data:    suggestions,
 	})
 }
 
@@ -85,22 +205,32 @@ func (h *Handler) UpdateUniversity(c *gin.Context) {
 
 	existingUniversity, err := h.repo.GetByID(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "university not found"})
+		respondError(c, err, "university not found")
 		return
 	}
 
-	university.ID = existingUniversity.ID
-	if err := h.repo.Update(c.Request.Context(), &university); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !checkIfMatch(c, existingUniversity) {
 		return
 	}
 
-	// Publicar evento no Kafka
-	if err := h.kafka.PublishUniversityEvent(c.Request.Context(), "university_updated", &university); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to publish event"})
+	university.ID = existingUniversity.ID
+	university.CreatedAt = existingUniversity.CreatedAt
+	university.Version = existingUniversity.Version
+	university.DeletedAt = existingUniversity.DeletedAt
+	err = h.events.persist(c.Request.Context(), func(ctx context.Context) ([]*models.UniversityEvent, error) {
+		if err := h.repo.Update(ctx, &university); err != nil {
+			return nil, err
+		}
+		event := newEvent(ctx, models.EventUniversityUpdated, &university)
+		event.SetChanges(existingUniversity, &university)
+		return []*models.UniversityEvent{event}, nil
+	})
+	if err != nil {
+		respondWriteError(c, err)
 		return
 	}
 
+	setETag(c, &university)
 	c.JSON(http.StatusOK, models.UniversityResponse{
 		Status:  http.StatusOK,
 		Message: "University updated successfully",
@@ -113,18 +243,22 @@ func (h *Handler) DeleteUniversity(c *gin.Context) {
 	
 	university, err := h.repo.GetByID(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "university not found"})
+		respondError(c, err, "university not found")
 		return
 	}
 
-	if err := h.repo.Delete(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !checkIfMatch(c, university) {
 		return
 	}
 
-	// Publicar evento no Kafka
-	if err := h.kafka.PublishUniversityEvent(c.Request.Context(), "university_deleted", university); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to publish event"})
+	err = h.events.persist(c.Request.Context(), func(ctx context.Context) ([]*models.UniversityEvent, error) {
+		if err := h.repo.Delete(ctx, university); err != nil {
+			return nil, err
+		}
+		return []*models.UniversityEvent{newEvent(ctx, models.EventUniversityDeleted, university)}, nil
+	})
+	if err != nil {
+		respondWriteError(c, err)
 		return
 	}
 
@@ -132,4 +266,39 @@ func (h *Handler) DeleteUniversity(c *gin.Context) {
 		"status":  http.StatusOK,
 		"message": "University deleted successfully",
 	})
-}
\ No newline at end of file
+}
+
+func (h *Handler) RestoreUniversity(c *gin.Context) {
+	university, err := h.repo.GetByIDIncludingDeleted(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err, "university not found")
+		return
+	}
+
+	if university.DeletedAt == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "university is not deleted"})
+		return
+	}
+
+	if !checkIfMatch(c, university) {
+		return
+	}
+
+	err = h.events.persist(c.Request.Context(), func(ctx context.Context) ([]*models.UniversityEvent, error) {
+		if err := h.repo.Restore(ctx, university); err != nil {
+			return nil, err
+		}
+		return []*models.UniversityEvent{newEvent(ctx, models.EventUniversityRestored, university)}, nil
+	})
+	if err != nil {
+		respondWriteError(c, err)
+		return
+	}
+
+	setETag(c, university)
+	c.JSON(http.StatusOK, models.UniversityResponse{
+		Status:  http.StatusOK,
+		Message: "University restored successfully",
+		Data:    *university,
+	})
+}
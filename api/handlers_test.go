@@ -4,91 +4,121 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/university-service/internal/models"
+	"github.com/university-service/internal/repository"
+	"github.com/university-service/internal/service"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-// Mock do UniversityRepository
-type MockUniversityRepository struct {
-	mock.Mock
+// recordedEvents guarda os eventos entregues pelo MemoryBus.
+type recordedEvents struct {
+	mu     sync.Mutex
+	events []*models.UniversityEvent
 }
 
-func (m *MockUniversityRepository) Create(ctx context.Context, university *models.University) error {
-	args := m.Called(ctx, university)
-	return args.Error(0)
+func (r *recordedEvents) handle(ctx context.Context, event *models.UniversityEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
 }
 
-func (m *MockUniversityRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*models.University, error) {
-	args := m.Called(ctx, id)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
+func (r *recordedEvents) types() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	types := make([]string, 0, len(r.events))
+	for _, event := range r.events {
+		types = append(types, event.Type)
 	}
-	return args.Get(0).(*models.University), args.Error(1)
+	return types
 }
 
-func (m *MockUniversityRepository) GetAll(ctx context.Context) ([]*models.University, error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]*models.University), args.Error(1)
-}
+func setupTestRouter(t *testing.T) (*gin.Engine, *repository.MemoryStore, *recordedEvents) {
+	gin.SetMode(gin.TestMode)
 
-func (m *MockUniversityRepository) Update(ctx context.Context, university *models.University) error {
-	args := m.Called(ctx, university)
-	return args.Error(0)
-}
+	store := repository.NewMemoryStore()
+	bus := service.NewMemoryBus()
+	events := &recordedEvents{}
+	t.Cleanup(bus.Subscribe(events.handle))
 
-func (m *MockUniversityRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
-	args := m.Called(ctx, id)
-	return args.Error(0)
+	handler := NewHandler(store, bus, nil)
+	r := gin.New()
+	r.POST("/universities", handler.CreateUniversity)
+	r.GET("/universities/search", handler.SearchUniversities)
+	r.GET("/universities/autocomplete", handler.AutocompleteUniversities)
+	r.GET("/universities/:id", handler.GetUniversity)
+	r.GET("/universities", handler.ListUniversities)
+	r.PUT("/universities/:id", handler.UpdateUniversity)
+	r.PATCH("/universities/:id", handler.PatchUniversity)
+	r.DELETE("/universities/:id", handler.DeleteUniversity)
+	r.POST("/universities/:id/restore", handler.RestoreUniversity)
+	r.GET("/universities/:id/revisions", handler.ListRevisions)
+	r.GET("/universities/:id/revisions/diff", handler.DiffRevisions)
+	r.GET("/universities/:id/revisions/:rev", handler.GetRevision)
+	r.POST("/universities/:id/revisions/:rev/revert", handler.RevertUniversity)
+	return r, store, events
 }
 
-// Mock do KafkaService
-type MockKafkaService struct {
-	mock.Mock
+func newTestUniversity(name string) *models.University {
+	return &models.University{
+		Name:    name,
+		Address: "123 Test St",
+		Phone:   "(11) 1234-5678",
+		Email:   "test@university.edu",
+		Website: "https://test.edu",
+	}
 }
 
-func (m *MockKafkaService) PublishUniversityEvent(ctx context.Context, eventType string, university *models.University) error {
-	args := m.Called(ctx, eventType, university)
-	return args.Error(0)
-}
+func TestHandler_CreateUniversity(t *testing.T) {
+	router, store, events := setupTestRouter(t)
 
-func (m *MockKafkaService) Close() error {
-	args := m.Called()
-	return args.Error(0)
-}
+	t.Run("Successful Creation", func(t *testing.T) {
+		body, _ := json.Marshal(newTestUniversity("Test University"))
+		req := httptest.NewRequest("POST", "/universities", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
 
-func setupTestRouter(repo *MockUniversityRepository, kafka *MockKafkaService) *gin.Engine {
-	gin.SetMode(gin.TestMode)
-	r := gin.New()
-	handler := NewHandler(repo, kafka)
-	handler.RegisterRoutes(r)
-	return r
-}
+		router.ServeHTTP(w, req)
 
-func TestHandler_CreateUniversity(t *testing.T) {
-	repo := new(MockUniversityRepository)
-	kafka := new(MockKafkaService)
-	router := setupTestRouter(repo, kafka)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var response models.UniversityResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "University created successfully", response.Message)
+		assert.False(t, response.Data.ID.IsZero())
+		assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+
+		saved, err := store.GetByID(context.Background(), response.Data.ID.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, "Test University", saved.Name)
+		assert.Equal(t, []string{models.EventUniversityCreated}, events.types())
+	})
 
-	t.Run("Successful Creation", func(t *testing.T) {
-		uni := &models.University{
-			Name:    "Test University",
-			Address: "123 Test St",
-			Phone:   "(11) 1234-5678",
-			Email:   "test@university.edu",
-			Website: "https://test.edu",
-		}
+	t.Run("Invalid Body", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/universities", bytes.NewBufferString(`{"name": "Missing Fields"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
 
-		repo.On("Create", mock.Anything, mock.AnythingOfType("*models.University")).Return(nil)
-		kafka.On("PublishUniversityEvent", mock.Anything, "university_created", mock.AnythingOfType("*models.University")).Return(nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
 
+	t.Run("Ignores Deleted At", func(t *testing.T) {
+		uni := newTestUniversity("Born Active")
+		deletedAt := time.Now()
+		uni.DeletedAt = &deletedAt
 		body, _ := json.Marshal(uni)
 		req := httptest.NewRequest("POST", "/universities", bytes.NewBuffer(body))
 		req.Header.Set("Content-Type", "application/json")
@@ -98,118 +128,138 @@ func TestHandler_CreateUniversity(t *testing.T) {
 
 		assert.Equal(t, http.StatusCreated, w.Code)
 		var response models.UniversityResponse
-		err := json.Unmarshal(w.Body.Bytes(), &response)
-		assert.NoError(t, err)
-		assert.Equal(t, "Universidade criada com sucesso", response.Message)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Nil(t, response.Data.DeletedAt)
+
+		saved, err := store.GetByID(context.Background(), response.Data.ID.Hex())
+		require.NoError(t, err)
+		assert.Nil(t, saved.DeletedAt)
+		last := events.events[len(events.events)-1]
+		assert.Equal(t, models.EventUniversityCreated, last.Type)
+		assert.Nil(t, last.University.DeletedAt)
+	})
+
+	t.Run("Duplicate ID", func(t *testing.T) {
+		existing := newTestUniversity("Existing University")
+		require.NoError(t, store.Create(context.Background(), existing))
+
+		duplicate := newTestUniversity("Duplicate University")
+		duplicate.ID = existing.ID
+		body, _ := json.Marshal(duplicate)
+		req := httptest.NewRequest("POST", "/universities", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
 
-		repo.AssertExpectations(t)
-		kafka.AssertExpectations(t)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.JSONEq(t, `{"error": "university already exists"}`, w.Body.String())
 	})
 }
 
 func TestHandler_GetUniversity(t *testing.T) {
-	repo := new(MockUniversityRepository)
-	kafka := new(MockKafkaService)
-	router := setupTestRouter(repo, kafka)
+	router, store, _ := setupTestRouter(t)
 
-	t.Run("Successful Retrieval", func(t *testing.T) {
-		id := primitive.NewObjectID()
-		uni := &models.University{
-			ID:        id,
-			Name:      "Test University",
-			Address:   "123 Test St",
-			Phone:    "(11) 1234-5678",
-			Email:     "test@university.edu",
-			Website:   "https://test.edu",
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
-		}
+	uni := newTestUniversity("Test University")
+	require.NoError(t, store.Create(context.Background(), uni))
 
-		repo.On("GetByID", mock.Anything, id).Return(uni, nil)
-
-		req := httptest.NewRequest("GET", "/universities/"+id.Hex(), nil)
+	t.Run("Successful Retrieval", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/universities/"+uni.ID.Hex(), nil)
 		w := httptest.NewRecorder()
 
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusOK, w.Code)
-		var response models.University
+		var response models.UniversityResponse
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
-		assert.Equal(t, uni.Name, response.Name)
+		assert.Equal(t, uni.Name, response.Data.Name)
+		assert.Equal(t, uni.ID, response.Data.ID)
+	})
 
-		repo.AssertExpectations(t)
+	t.Run("Not Found", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/universities/"+primitive.NewObjectID().Hex(), nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/universities/invalid-id", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"error": "invalid university id"}`, w.Body.String())
 	})
 }
 
-func TestHandler_ListUniversities(t *testing.T) {
-	repo := new(MockUniversityRepository)
-	kafka := new(MockKafkaService)
-	router := setupTestRouter(repo, kafka)
+func TestRespondError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
 
-	t.Run("Successful List", func(t *testing.T) {
-		universities := []*models.University{
-			{
-				ID:        primitive.NewObjectID(),
-				Name:      "University 1",
-				Address:   "123 Test St",
-				Phone:    "(11) 1234-5678",
-				Email:     "test1@university.edu",
-				Website:   "https://test1.edu",
-				CreatedAt: time.Now(),
-				UpdatedAt: time.Now(),
-			},
-			{
-				ID:        primitive.NewObjectID(),
-				Name:      "University 2",
-				Address:   "456 Test St",
-				Phone:    "(11) 8765-4321",
-				Email:     "test2@university.edu",
-				Website:   "https://test2.edu",
-				CreatedAt: time.Now(),
-				UpdatedAt: time.Now(),
-			},
-		}
+	tests := []struct {
+		err     error
+		status  int
+		message string
+	}{
+		{fmt.Errorf("%w: %q", repository.ErrInvalidID, "x"), http.StatusBadRequest, "invalid university id"},
+		{repository.ErrInvalidCursor, http.StatusBadRequest, "invalid cursor"},
+		{repository.ErrNotFound, http.StatusNotFound, "university not found"},
+		{repository.ErrDuplicate, http.StatusConflict, "university already exists"},
+		{repository.ErrVersionConflict, http.StatusPreconditionFailed, "precondition failed: university was modified concurrently"},
+		{fmt.Errorf("%w: connection refused", repository.ErrUnavailable), http.StatusServiceUnavailable, "storage unavailable, try again later"},
+		{errors.New("driver: secret details"), http.StatusInternalServerError, "internal server error"},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		respondError(c, tt.err, "university not found")
+
+		assert.Equal(t, tt.status, w.Code, tt.err.Error())
+		assert.JSONEq(t, `{"error": "`+tt.message+`"}`, w.Body.String())
+	}
+}
+
+func TestHandler_ListUniversities(t *testing.T) {
+	router, store, _ := setupTestRouter(t)
 
-		repo.On("GetAll", mock.Anything).Return(universities, nil)
+	for _, name := range []string{"University 1", "University 2"} {
+		require.NoError(t, store.Create(context.Background(), newTestUniversity(name)))
+	}
 
-		req := httptest.NewRequest("GET", "/universities", nil)
+	t.Run("Successful List", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/universities?sort=-name", nil)
 		w := httptest.NewRecorder()
 
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusOK, w.Code)
-		var response []*models.University
+		var response models.UniversityListResponse
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
-		assert.Len(t, response, 2)
-
-		repo.AssertExpectations(t)
+		require.Len(t, response.Data, 2)
+		assert.Equal(t, "University 2", response.Data[0].Name)
+		assert.Equal(t, int64(2), response.Pagination.Total)
 	})
 }
 
 func TestHandler_UpdateUniversity(t *testing.T) {
-	repo := new(MockUniversityRepository)
-	kafka := new(MockKafkaService)
-	router := setupTestRouter(repo, kafka)
+	router, store, events := setupTestRouter(t)
 
-	t.Run("Successful Update", func(t *testing.T) {
-		id := primitive.NewObjectID()
-		uni := &models.University{
-			ID:      id,
-			Name:    "Updated University",
-			Address: "789 Test St",
-			Phone:   "(11) 9999-9999",
-			Email:   "updated@university.edu",
-			Website: "https://updated.edu",
-		}
-
-		repo.On("Update", mock.Anything, mock.AnythingOfType("*models.University")).Return(nil)
-		kafka.On("PublishUniversityEvent", mock.Anything, "university_updated", mock.AnythingOfType("*models.University")).Return(nil)
+	uni := newTestUniversity("Test University")
+	require.NoError(t, store.Create(context.Background(), uni))
 
-		body, _ := json.Marshal(uni)
-		req := httptest.NewRequest("PUT", "/universities/"+id.Hex(), bytes.NewBuffer(body))
+	t.Run("Successful Update", func(t *testing.T) {
+		update := newTestUniversity("Updated University")
+		body, _ := json.Marshal(update)
+		req := httptest.NewRequest("PUT", "/universities/"+uni.ID.Hex(), bytes.NewBuffer(body))
 		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"1"`)
 		w := httptest.NewRecorder()
 
 		router.ServeHTTP(w, req)
@@ -218,45 +268,359 @@ func TestHandler_UpdateUniversity(t *testing.T) {
 		var response models.UniversityResponse
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
-		assert.Equal(t, "Universidade atualizada com sucesso", response.Message)
+		assert.Equal(t, "University updated successfully", response.Message)
+		assert.Equal(t, int64(2), response.Data.Version)
+
+		saved, err := store.GetByID(context.Background(), uni.ID.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, "Updated University", saved.Name)
+		assert.Equal(t, []string{models.EventUniversityUpdated}, events.types())
+	})
 
-		repo.AssertExpectations(t)
-		kafka.AssertExpectations(t)
+	t.Run("Stale Version", func(t *testing.T) {
+		body, _ := json.Marshal(newTestUniversity("Stale University"))
+		req := httptest.NewRequest("PUT", "/universities/"+uni.ID.Hex(), bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"1"`)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	})
+
+	t.Run("Ignores Deleted At", func(t *testing.T) {
+		update := newTestUniversity("Still Active")
+		deletedAt := time.Now()
+		update.DeletedAt = &deletedAt
+		body, _ := json.Marshal(update)
+		req := httptest.NewRequest("PUT", "/universities/"+uni.ID.Hex(), bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"2"`)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.UniversityResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Nil(t, response.Data.DeletedAt)
+
+		saved, err := store.GetByID(context.Background(), uni.ID.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, "Still Active", saved.Name)
+		assert.Nil(t, saved.DeletedAt)
 	})
 }
 
 func TestHandler_DeleteUniversity(t *testing.T) {
-	repo := new(MockUniversityRepository)
-	kafka := new(MockKafkaService)
-	router := setupTestRouter(repo, kafka)
+	router, store, events := setupTestRouter(t)
+
+	uni := newTestUniversity("University to Delete")
+	require.NoError(t, store.Create(context.Background(), uni))
 
 	t.Run("Successful Delete", func(t *testing.T) {
-		id := primitive.NewObjectID()
-		uni := &models.University{
-			ID:      id,
-			Name:    "University to Delete",
-			Address: "999 Test St",
-			Phone:   "(11) 0000-0000",
-			Email:   "delete@university.edu",
-			Website: "https://delete.edu",
-		}
+		req := httptest.NewRequest("DELETE", "/universities/"+uni.ID.Hex(), nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "University deleted successfully", response["message"])
+
+		_, err = store.GetByID(context.Background(), uni.ID.Hex())
+		assert.Error(t, err)
+		deleted, err := store.GetByIDIncludingDeleted(context.Background(), uni.ID.Hex())
+		require.NoError(t, err)
+		assert.NotNil(t, deleted.DeletedAt)
+		assert.Equal(t, []string{models.EventUniversityDeleted}, events.types())
+	})
+
+	t.Run("Already Deleted", func(t *testing.T) {
+		req := httptest.NewRequest("DELETE", "/universities/"+uni.ID.Hex(), nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestHandler_RestoreUniversity(t *testing.T) {
+	router, store, events := setupTestRouter(t)
 
-		repo.On("GetByID", mock.Anything, id).Return(uni, nil)
-		repo.On("Delete", mock.Anything, id).Return(nil)
-		kafka.On("PublishUniversityEvent", mock.Anything, "university_deleted", mock.AnythingOfType("*models.University")).Return(nil)
+	uni := newTestUniversity("University to Restore")
+	require.NoError(t, store.Create(context.Background(), uni))
+	require.NoError(t, store.Delete(context.Background(), uni))
 
-		req := httptest.NewRequest("DELETE", "/universities/"+id.Hex(), nil)
+	restore := func(id, ifMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/universities/"+id+"/restore", nil)
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
 		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
 
+	t.Run("Deleted Record Is Hidden", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/universities/"+uni.ID.Hex(), nil)
+		w := httptest.NewRecorder()
 		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		req = httptest.NewRequest("GET", "/universities/"+uni.ID.Hex()+"?include_deleted=true", nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Stale Version", func(t *testing.T) {
+		w := restore(uni.ID.Hex(), `"1"`)
+
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	})
+
+	t.Run("Successful Restore", func(t *testing.T) {
+		w := restore(uni.ID.Hex(), `"2"`)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 		var response models.UniversityResponse
-		err := json.Unmarshal(w.Body.Bytes(), &response)
-		assert.NoError(t, err)
-		assert.Equal(t, "Universidade excluída com sucesso", response.Message)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "University restored successfully", response.Message)
+		assert.Nil(t, response.Data.DeletedAt)
+		assert.Equal(t, int64(3), response.Data.Version)
+		assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+
+		saved, err := store.GetByID(context.Background(), uni.ID.Hex())
+		require.NoError(t, err)
+		assert.Nil(t, saved.DeletedAt)
+		assert.Equal(t, []string{models.EventUniversityRestored}, events.types())
+	})
+
+	t.Run("Not Deleted", func(t *testing.T) {
+		w := restore(uni.ID.Hex(), "")
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		w := restore("invalid", "")
 
-		repo.AssertExpectations(t)
-		kafka.AssertExpectations(t)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
-}
\ No newline at end of file
+
+	t.Run("Not Found", func(t *testing.T) {
+		w := restore(primitive.NewObjectID().Hex(), "")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+// createSearchFixtures grava universidades com nomes e endereços distintos
+// para as buscas, além de uma excluída que nunca deve aparecer.
+func createSearchFixtures(t *testing.T, store *repository.MemoryStore) map[string]*models.University {
+	ctx := context.Background()
+	fixtures := map[string]*models.University{}
+	for _, fixture := range []struct{ name, address string }{
+		{"Universidade de São Paulo", "Rua da Reitoria, São Paulo"},
+		{"Universidade Federal do Rio de Janeiro", "Av. Pedro Calmon, Rio de Janeiro"},
+		{"Paulista College", "Rua Central, Campinas"},
+		{"Universidade Extinta", "Rua Antiga, São Paulo"},
+	} {
+		uni := newTestUniversity(fixture.name)
+		uni.Address = fixture.address
+		require.NoError(t, store.Create(ctx, uni))
+		fixtures[fixture.name] = uni
+	}
+	require.NoError(t, store.Delete(ctx, fixtures["Universidade Extinta"]))
+	return fixtures
+}
+
+func TestHandler_SearchUniversities(t *testing.T) {
+	router, store, _ := setupTestRouter(t)
+	createSearchFixtures(t, store)
+
+	search := func(t *testing.T, query string) []models.SearchHit {
+		req := httptest.NewRequest("GET", "/universities/search?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response models.SearchResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data
+	}
+	names := func(hits []models.SearchHit) []string {
+		result := make([]string, 0, len(hits))
+		for _, hit := range hits {
+			result = append(result, hit.University.Name)
+		}
+		return result
+	}
+
+	t.Run("Ranks Name Above Address", func(t *testing.T) {
+		hits := search(t, "q=paulo")
+
+		assert.Equal(t, []string{"Universidade de São Paulo"}, names(hits))
+		assert.Equal(t, float64(15), hits[0].Score)
+		assert.Contains(t, hits[0].Highlights, "name")
+		assert.Contains(t, hits[0].Highlights, "address")
+	})
+
+	t.Run("Ignores Accents And Case", func(t *testing.T) {
+		assert.Equal(t, []string{"Universidade de São Paulo"}, names(search(t, "q=SAO")))
+	})
+
+	t.Run("Excluded Terms", func(t *testing.T) {
+		assert.Equal(t, []string{"Universidade de São Paulo"}, names(search(t, "q=universidade+-rio")))
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		assert.Len(t, search(t, "q=universidade&limit=1"), 1)
+	})
+
+	t.Run("No Results", func(t *testing.T) {
+		assert.Empty(t, search(t, "q=inexistente"))
+	})
+
+	t.Run("Invalid Parameters", func(t *testing.T) {
+		for _, query := range []string{"", "q=+", "q=paulo&limit=0", "q=paulo&limit=many", fmt.Sprintf("q=paulo&limit=%d", repository.MaxPageSize+1)} {
+			req := httptest.NewRequest("GET", "/universities/search?"+query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+	})
+}
+
+func TestHandler_AutocompleteUniversities(t *testing.T) {
+	router, store, _ := setupTestRouter(t)
+	fixtures := createSearchFixtures(t, store)
+
+	autocomplete := func(t *testing.T, query string) []models.Suggestion {
+		req := httptest.NewRequest("GET", "/universities/autocomplete?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response models.SuggestionResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data
+	}
+
+	t.Run("Sorted By Name", func(t *testing.T) {
+		suggestions := autocomplete(t, "prefix=univ")
+
+		require.Len(t, suggestions, 2)
+		assert.Equal(t, fixtures["Universidade de São Paulo"].ID, suggestions[0].ID)
+		assert.Equal(t, "Universidade de São Paulo", suggestions[0].Name)
+		assert.Equal(t, "Universidade Federal do Rio de Janeiro", suggestions[1].Name)
+	})
+
+	t.Run("Ignores Accents And Case", func(t *testing.T) {
+		suggestions := autocomplete(t, "prefix=UNIVERSIDADE+DE+SAO")
+
+		require.Len(t, suggestions, 1)
+		assert.Equal(t, "Universidade de São Paulo", suggestions[0].Name)
+	})
+
+	t.Run("Matches Only The Start", func(t *testing.T) {
+		assert.Empty(t, autocomplete(t, "prefix=paulo"))
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		assert.Len(t, autocomplete(t, "prefix=univ&limit=1"), 1)
+	})
+
+	t.Run("Invalid Parameters", func(t *testing.T) {
+		for _, query := range []string{"", "prefix=+", "prefix=univ&limit=0", "prefix=univ&limit=many", fmt.Sprintf("prefix=univ&limit=%d", repository.MaxAutocompleteLimit+1)} {
+			req := httptest.NewRequest("GET", "/universities/autocomplete?"+query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+	})
+}
+
+func TestHandler_ListUniversities_Pagination(t *testing.T) {
+	router, store, _ := setupTestRouter(t)
+
+	for i := 1; i <= 5; i++ {
+		require.NoError(t, store.Create(context.Background(), newTestUniversity(fmt.Sprintf("University %d", i))))
+	}
+	other := newTestUniversity("Other College")
+	other.Email = "contact@college.org"
+	require.NoError(t, store.Create(context.Background(), other))
+
+	list := func(t *testing.T, query string) models.UniversityListResponse {
+		req := httptest.NewRequest("GET", "/universities?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response models.UniversityListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+	names := func(universities []models.University) []string {
+		result := make([]string, 0, len(universities))
+		for _, university := range universities {
+			result = append(result, university.Name)
+		}
+		return result
+	}
+
+	t.Run("Middle Page", func(t *testing.T) {
+		response := list(t, "page=2&page_size=2&sort=name&email_domain=university.edu")
+
+		assert.Equal(t, []string{"University 3", "University 4"}, names(response.Data))
+		assert.Equal(t, &models.Pagination{Page: 2, PageSize: 2, Offset: 2, Total: 5, TotalPages: 3}, response.Pagination)
+		assert.Equal(t, "/universities?email_domain=university.edu&limit=2&offset=2&sort=name", response.Links.Self)
+		assert.Equal(t, "/universities?email_domain=university.edu&limit=2&offset=4&sort=name", response.Links.Next)
+		assert.Equal(t, "/universities?email_domain=university.edu&limit=2&offset=0&sort=name", response.Links.Prev)
+	})
+
+	t.Run("Following Links", func(t *testing.T) {
+		response := list(t, "limit=2&offset=4&sort=name&email_domain=university.edu")
+
+		assert.Equal(t, []string{"University 5"}, names(response.Data))
+		assert.Equal(t, int64(3), response.Pagination.Page)
+		assert.Empty(t, response.Links.Next)
+		assert.Equal(t, "/universities?email_domain=university.edu&limit=2&offset=2&sort=name", response.Links.Prev)
+	})
+
+	t.Run("First Page", func(t *testing.T) {
+		response := list(t, "limit=4&sort=-name")
+
+		assert.Equal(t, []string{"University 5", "University 4", "University 3", "University 2"}, names(response.Data))
+		assert.Equal(t, int64(6), response.Pagination.Total)
+		assert.Equal(t, int64(2), response.Pagination.TotalPages)
+		assert.Equal(t, "/universities?limit=4&offset=4&sort=-name", response.Links.Next)
+		assert.Empty(t, response.Links.Prev)
+	})
+
+	t.Run("Beyond Last Page", func(t *testing.T) {
+		response := list(t, "page=10&page_size=2")
+
+		assert.Empty(t, response.Data)
+		assert.Equal(t, int64(6), response.Pagination.Total)
+		assert.Empty(t, response.Links.Next)
+		assert.NotEmpty(t, response.Links.Prev)
+	})
+
+	t.Run("Invalid Parameters", func(t *testing.T) {
+		for _, query := range []string{"page=0", "page_size=0", fmt.Sprintf("limit=%d", repository.MaxPageSize+1), "offset=-1", "sort=unknown", "include_deleted=maybe"} {
+			req := httptest.NewRequest("GET", "/universities?"+query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/university-service/internal/repository"
+	"github.com/university-service/internal/service"
+)
+
+// RequireAdminToken protege as rotas administrativas exigindo o token
+// configurado em admin.token, enviado como "Authorization: Bearer <token>" ou
+// no cabeçalho X-Admin-Token. Sem token configurado, as rotas ficam fechadas.
+func RequireAdminToken(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin endpoints are disabled"})
+			return
+		}
+
+		provided := c.GetHeader("X-Admin-Token")
+		if bearer, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok {
+			provided = bearer
+		}
+
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid admin token"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequestActor identifica quem está fazendo a alteração pelo cabeçalho
+// X-User-ID e repassa essa informação ao repositório pelo contexto, para que
+// fique registrada no histórico de revisões.
+func RequestActor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if actor := c.GetHeader("X-User-ID"); actor != "" {
+			c.Request = c.Request.WithContext(repository.WithActor(c.Request.Context(), actor))
+		}
+		c.Next()
+	}
+}
+
+// RequestID garante que toda requisição tenha um identificador, lido de
+// X-Request-ID (ou X-Correlation-ID) ou gerado, devolvido na resposta e
+// propagado para os eventos publicados.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader("X-Request-ID")
+		if id == "" {
+			id = c.GetHeader("X-Correlation-ID")
+		}
+		if id == "" {
+			id = uuid.NewString()
+		}
+
+		c.Header("X-Request-ID", id)
+		c.Request = c.Request.WithContext(service.WithCorrelationID(c.Request.Context(), id))
+		c.Next()
+	}
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/university-service/internal/models"
+	"github.com/university-service/internal/repository"
+)
+
+// parseListOptions lê os parâmetros de paginação, filtro e ordenação da query.
+// Aceita tanto page/page_size quanto limit/offset; limit/offset têm prioridade.
+func parseListOptions(c *gin.Context) (repository.ListOptions, error) {
+	opts := repository.ListOptions{
+		Name:        c.Query("name"),
+		EmailDomain: c.Query("email_domain"),
+		Website:     c.Query("website"),
+	}
+
+	includeDeleted, err := queryBool(c, "include_deleted")
+	if err != nil {
+		return opts, err
+	}
+	opts.IncludeDeleted = includeDeleted
+
+	pageSize, err := queryInt(c, "page_size", repository.DefaultPageSize)
+	if err != nil {
+		return opts, err
+	}
+	page, err := queryInt(c, "page", 1)
+	if err != nil {
+		return opts, err
+	}
+	if page < 1 {
+		return opts, fmt.Errorf("page must be greater than zero")
+	}
+
+	limit, err := queryInt(c, "limit", pageSize)
+	if err != nil {
+		return opts, err
+	}
+	if limit < 1 || limit > repository.MaxPageSize {
+		return opts, fmt.Errorf("page size must be between 1 and %d", repository.MaxPageSize)
+	}
+
+	offset, err := queryInt(c, "offset", (page-1)*limit)
+	if err != nil {
+		return opts, err
+	}
+	if offset < 0 {
+		return opts, fmt.Errorf("offset must not be negative")
+	}
+
+	opts.Limit = limit
+	opts.Offset = offset
+
+	if sort := c.Query("sort"); sort != "" {
+		fields, err := repository.ParseSort(sort)
+		if err != nil {
+			return opts, err
+		}
+		opts.Sort = fields
+	}
+
+	return opts, nil
+}
+
+func queryInt(c *gin.Context, key string, fallback int64) (int64, error) {
+	value, ok := c.GetQuery(key)
+	if !ok || value == "" {
+		return fallback, nil
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value for %s: %s", key, value)
+	}
+	return n, nil
+}
+
+func queryBool(c *gin.Context, key string) (bool, error) {
+	value, ok := c.GetQuery(key)
+	if !ok || value == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid value for %s: %s", key, value)
+	}
+	return b, nil
+}
+
+func buildPagination(opts repository.ListOptions, total int64) *models.Pagination {
+	totalPages := (total + opts.Limit - 1) / opts.Limit
+	return &models.Pagination{
+		Page:       opts.Offset/opts.Limit + 1,
+		PageSize:   opts.Limit,
+		Offset:     opts.Offset,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+}
+
+// buildLinks monta os links da página atual, da próxima e da anterior
+// preservando os filtros e a ordenação da requisição original.
+func buildLinks(c *gin.Context, opts repository.ListOptions, total int64) models.Links {
+	pageURL := func(offset int64) string {
+		query := url.Values{}
+		for key, values := range c.Request.URL.Query() {
+			switch key {
+			case "page", "page_size", "limit", "offset":
+				continue
+			}
+			query[key] = values
+		}
+		query.Set("limit", strconv.FormatInt(opts.Limit, 10))
+		query.Set("offset", strconv.FormatInt(offset, 10))
+		return c.Request.URL.Path + "?" + query.Encode()
+	}
+
+	links := models.Links{Self: pageURL(opts.Offset)}
+	if opts.Offset+opts.Limit < total {
+		links.Next = pageURL(opts.Offset + opts.Limit)
+	}
+	if opts.Offset > 0 {
+		prev := opts.Offset - opts.Limit
+		if prev < 0 {
+			prev = 0
+		}
+		links.Prev = pageURL(prev)
+	}
+	return links
+}
+
+// buildCursorLinks monta os links do modo cursor, em que só existe avanço.
+func buildCursorLinks(c *gin.Context, cursor, nextCursor string) models.Links {
+	pageURL := func(cursor string) string {
+		query := c.Request.URL.Query()
+		query.Set("cursor", cursor)
+		return c.Request.URL.Path + "?" + query.Encode()
+	}
+
+	links := models.Links{Self: pageURL(cursor)}
+	if nextCursor != "" {
+		links.Next = pageURL(nextCursor)
+	}
+	return links
+}
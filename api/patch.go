@@ -0,0 +1,160 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/university-service/internal/models"
+)
+
+const (
+	mergePatchContentType = "application/merge-patch+json"
+	jsonPatchContentType  = "application/json-patch+json"
+)
+
+// PatchUniversity aplica uma atualização parcial no formato JSON Merge Patch
+// (RFC 7396) ou JSON Patch (RFC 6902), conforme o Content-Type, e grava apenas
+// os campos que efetivamente mudaram.
+func (h *Handler) PatchUniversity(c *gin.Context) {
+	mediaType, _, err := mime.ParseMediaType(c.ContentType())
+	if err != nil {
+		mediaType = ""
+	}
+	switch mediaType {
+	case mergePatchContentType, binding.MIMEJSON, jsonPatchContentType:
+	default:
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{
+			"error": "content type must be " + mergePatchContentType + " or " + jsonPatchContentType,
+		})
+		return
+	}
+
+	patch, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	existingUniversity, err := h.repo.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err, "university not found")
+		return
+	}
+
+	if !checkIfMatch(c, existingUniversity) {
+		return
+	}
+
+	original, err := json.Marshal(existingUniversity)
+	if err != nil {
+		respondError(c, err, "university not found")
+		return
+	}
+
+	var merged []byte
+	if mediaType == jsonPatchContentType {
+		merged, err = applyJSONPatch(original, patch)
+	} else {
+		merged, err = jsonpatch.MergePatch(original, patch)
+		if err != nil {
+			err = &patchError{status: http.StatusBadRequest, err: err}
+		}
+	}
+	if err != nil {
+		var pe *patchError
+		if errors.As(err, &pe) {
+			c.JSON(pe.status, gin.H{"error": pe.Error()})
+			return
+		}
+		respondError(c, err, "university not found")
+		return
+	}
+
+	var university models.University
+	if err := json.Unmarshal(merged, &university); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Campos controlados pelo serviço não podem ser alterados pelo patch
+	university.ID = existingUniversity.ID
+	university.CreatedAt = existingUniversity.CreatedAt
+	university.UpdatedAt = existingUniversity.UpdatedAt
+	university.Version = existingUniversity.Version
+	university.DeletedAt = existingUniversity.DeletedAt
+
+	if err := binding.Validator.ValidateStruct(&university); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	changedFields := models.ChangedFields(existingUniversity, &university)
+	if len(changedFields) == 0 {
+		setETag(c, existingUniversity)
+		c.JSON(http.StatusOK, models.UniversityResponse{
+			Status:  http.StatusOK,
+			Message: "University not modified",
+			Data:    *existingUniversity,
+		})
+		return
+	}
+
+	err = h.events.persist(c.Request.Context(), func(ctx context.Context) ([]*models.UniversityEvent, error) {
+		if err := h.repo.UpdateFields(ctx, &university, changedFields); err != nil {
+			return nil, err
+		}
+		event := newEvent(ctx, models.EventUniversityPatched, &university)
+		event.SetChanges(existingUniversity, &university)
+		return []*models.UniversityEvent{event}, nil
+	})
+	if err != nil {
+		respondWriteError(c, err)
+		return
+	}
+
+	setETag(c, &university)
+	c.JSON(http.StatusOK, models.UniversityResponse{
+		Status:  http.StatusOK,
+		Message: "University patched successfully",
+		Data:    university,
+	})
+}
+
+// patchError carrega o status HTTP correspondente a uma falha ao aplicar o patch.
+type patchError struct {
+	status int
+	err    error
+}
+
+func (e *patchError) Error() string {
+	return "invalid patch: " + e.err.Error()
+}
+
+func (e *patchError) Unwrap() error {
+	return e.err
+}
+
+// applyJSONPatch aplica as operações de um JSON Patch (RFC 6902). Uma operação
+// "test" que falha resulta em 409, permitindo edições condicionais sem uma
+// leitura prévia; operações que não podem ser aplicadas resultam em 422.
+func applyJSONPatch(original, body []byte) ([]byte, error) {
+	patch, err := jsonpatch.DecodePatch(body)
+	if err != nil {
+		return nil, &patchError{status: http.StatusBadRequest, err: err}
+	}
+
+	patched, err := patch.Apply(original)
+	switch {
+	case errors.Is(err, jsonpatch.ErrTestFailed):
+		return nil, &patchError{status: http.StatusConflict, err: err}
+	case err != nil:
+		return nil, &patchError{status: http.StatusUnprocessableEntity, err: err}
+	}
+	return patched, nil
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/university-service/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func servePatch(router *gin.Engine, id, contentType, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("PATCH", "/universities/"+id, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_PatchUniversity_MergePatch(t *testing.T) {
+	router, store, events := setupTestRouter(t)
+
+	uni := newTestUniversity("Test University")
+	require.NoError(t, store.Create(context.Background(), uni))
+
+	t.Run("Successful Patch", func(t *testing.T) {
+		w := servePatch(router, uni.ID.Hex(), mergePatchContentType, `{"phone": "(21) 9999-0000"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.UniversityResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "University patched successfully", response.Message)
+		assert.Equal(t, "(21) 9999-0000", response.Data.Phone)
+		assert.Equal(t, "Test University", response.Data.Name)
+		assert.Equal(t, `"2"`, w.Header().Get("ETag"))
+
+		saved, err := store.GetByID(context.Background(), uni.ID.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, "(21) 9999-0000", saved.Phone)
+		assert.Equal(t, []string{models.EventUniversityPatched}, events.types())
+		assert.Equal(t, []string{"phone"}, events.events[0].ChangedFields)
+	})
+
+	t.Run("Ignores Service Fields", func(t *testing.T) {
+		other := primitive.NewObjectID().Hex()
+		body := `{"id": "` + other + `", "version": 99, "deleted_at": "2024-01-01T00:00:00Z", "website": "https://new.edu"}`
+		w := servePatch(router, uni.ID.Hex(), mergePatchContentType, body)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.UniversityResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, uni.ID, response.Data.ID)
+		assert.Equal(t, int64(3), response.Data.Version)
+		assert.Nil(t, response.Data.DeletedAt)
+
+		saved, err := store.GetByID(context.Background(), uni.ID.Hex())
+		require.NoError(t, err)
+		assert.Nil(t, saved.DeletedAt)
+		last := events.events[len(events.events)-1]
+		assert.Nil(t, last.University.DeletedAt)
+		assert.Equal(t, []string{"website"}, last.ChangedFields)
+	})
+
+	t.Run("Not Modified", func(t *testing.T) {
+		w := servePatch(router, uni.ID.Hex(), mergePatchContentType, `{"website": "https://new.edu"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "University not modified")
+		assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+	})
+
+	t.Run("Error Paths", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			id          string
+			contentType string
+			body        string
+			status      int
+		}{
+			{"Unsupported Media Type", uni.ID.Hex(), "text/plain", `{}`, http.StatusUnsupportedMediaType},
+			{"Malformed Patch", uni.ID.Hex(), mergePatchContentType, `{`, http.StatusBadRequest},
+			{"Validation Failure", uni.ID.Hex(), mergePatchContentType, `{"email": "not an email"}`, http.StatusBadRequest},
+			{"Required Field Removed", uni.ID.Hex(), mergePatchContentType, `{"name": null}`, http.StatusBadRequest},
+			{"Invalid ID", "invalid", mergePatchContentType, `{}`, http.StatusBadRequest},
+			{"Not Found", primitive.NewObjectID().Hex(), mergePatchContentType, `{}`, http.StatusNotFound},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				w := servePatch(router, tt.id, tt.contentType, tt.body)
+				assert.Equal(t, tt.status, w.Code)
+			})
+		}
+	})
+}
+
+func TestHandler_PatchUniversity_JSONPatch(t *testing.T) {
+	router, store, events := setupTestRouter(t)
+
+	uni := newTestUniversity("Test University")
+	require.NoError(t, store.Create(context.Background(), uni))
+
+	t.Run("Successful Patch", func(t *testing.T) {
+		body := `[
+			{"op": "test", "path": "/name", "value": "Test University"},
+			{"op": "replace", "path": "/name", "value": "Renamed University"},
+			{"op": "copy", "from": "/website", "path": "/address"}
+		]`
+		w := servePatch(router, uni.ID.Hex(), jsonPatchContentType, body)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.UniversityResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "Renamed University", response.Data.Name)
+		assert.Equal(t, "https://test.edu", response.Data.Address)
+
+		saved, err := store.GetByID(context.Background(), uni.ID.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, "Renamed University", saved.Name)
+		assert.Equal(t, []string{models.EventUniversityPatched}, events.types())
+		assert.ElementsMatch(t, []string{"name", "address"}, events.events[0].ChangedFields)
+	})
+
+	t.Run("Error Paths", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			body   string
+			status int
+		}{
+			{"Malformed Patch", `{"op": "replace"}`, http.StatusBadRequest},
+			{"Failed Test", `[{"op": "test", "path": "/name", "value": "Test University"}]`, http.StatusConflict},
+			{"Missing Path", `[{"op": "remove", "path": "/missing"}]`, http.StatusUnprocessableEntity},
+			{"Validation Failure", `[{"op": "replace", "path": "/email", "value": "not an email"}]`, http.StatusBadRequest},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				w := servePatch(router, uni.ID.Hex(), jsonPatchContentType, tt.body)
+				assert.Equal(t, tt.status, w.Code)
+			})
+		}
+
+		saved, err := store.GetByID(context.Background(), uni.ID.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), saved.Version)
+	})
+}
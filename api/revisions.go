@@ -0,0 +1,160 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/university-service/internal/models"
+)
+
+func (h *Handler) ListRevisions(c *gin.Context) {
+	revisions, err := h.repo.ListRevisions(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err, "university not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.RevisionListResponse{
+		Status:  http.StatusOK,
+		Message: "Revisions retrieved successfully",
+		Data:    revisions,
+	})
+}
+
+func (h *Handler) GetRevision(c *gin.Context) {
+	rev, err := strconv.ParseInt(c.Param("rev"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid revision: " + c.Param("rev")})
+		return
+	}
+
+	revision, err := h.repo.GetRevision(c.Request.Context(), c.Param("id"), rev)
+	if err != nil {
+		respondError(c, err, "revision not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.RevisionResponse{
+		Status:  http.StatusOK,
+		Message: "Revision retrieved successfully",
+		Data:    *revision,
+	})
+}
+
+// DiffRevisions compara duas versões de uma universidade. Os parâmetros from e
+// to aceitam números de revisão; se to for omitido, a comparação é feita com o
+// estado atual.
+func (h *Handler) DiffRevisions(c *gin.Context) {
+	id := c.Param("id")
+
+	from, err := strconv.ParseInt(c.Query("from"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "query parameter from must be a revision number"})
+		return
+	}
+
+	before, err := h.snapshotAt(c.Request.Context(), id, from)
+	if err != nil {
+		respondError(c, err, "revision not found")
+		return
+	}
+
+	var after *models.University
+	if value := c.Query("to"); value != "" {
+		to, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "query parameter to must be a revision number"})
+			return
+		}
+		after, err = h.snapshotAt(c.Request.Context(), id, to)
+		if err != nil {
+			respondError(c, err, "revision not found")
+			return
+		}
+	} else {
+		after, err = h.repo.GetByIDIncludingDeleted(c.Request.Context(), id)
+		if err != nil {
+			respondError(c, err, "university not found")
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, models.RevisionDiffResponse{
+		Status:  http.StatusOK,
+		Message: "Revision diff computed successfully",
+		Data: models.RevisionDiff{
+			UniversityID: before.ID,
+			From:         before.Version,
+			To:           after.Version,
+			Changes:      models.Diff(before, after),
+		},
+	})
+}
+
+// snapshotAt retorna a universidade como estava na revisão informada, que pode
+// ser uma revisão guardada ou a versão atual do documento.
+func (h *Handler) snapshotAt(ctx context.Context, id string, rev int64) (*models.University, error) {
+	current, err := h.repo.GetByIDIncludingDeleted(ctx, id)
+	if err == nil && current.Version == rev {
+		return current, nil
+	}
+
+	revision, err := h.repo.GetRevision(ctx, id, rev)
+	if err != nil {
+		return nil, err
+	}
+	return &revision.Snapshot, nil
+}
+
+// RevertUniversity restaura a universidade para o snapshot de uma revisão. O
+// estado substituído fica guardado como uma nova revisão.
+func (h *Handler) RevertUniversity(c *gin.Context) {
+	rev, err := strconv.ParseInt(c.Param("rev"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid revision: " + c.Param("rev")})
+		return
+	}
+
+	current, err := h.repo.GetByIDIncludingDeleted(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err, "university not found")
+		return
+	}
+
+	if !checkIfMatch(c, current) {
+		return
+	}
+
+	revision, err := h.repo.GetRevision(c.Request.Context(), c.Param("id"), rev)
+	if err != nil {
+		respondError(c, err, "revision not found")
+		return
+	}
+
+	university := revision.Snapshot
+	university.ID = current.ID
+	university.CreatedAt = current.CreatedAt
+	university.Version = current.Version
+
+	err = h.events.persist(c.Request.Context(), func(ctx context.Context) ([]*models.UniversityEvent, error) {
+		if err := h.repo.Revert(ctx, &university); err != nil {
+			return nil, err
+		}
+		event := newEvent(ctx, models.EventUniversityReverted, &university)
+		event.SetChanges(current, &university)
+		return []*models.UniversityEvent{event}, nil
+	})
+	if err != nil {
+		respondWriteError(c, err)
+		return
+	}
+
+	setETag(c, &university)
+	c.JSON(http.StatusOK, models.UniversityResponse{
+		Status:  http.StatusOK,
+		Message: "University reverted successfully",
+		Data:    university,
+	})
+}
@@ -0,0 +1,214 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/university-service/internal/models"
+	"github.com/university-service/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// setupRevisions cria uma universidade com duas alterações, deixando as
+// revisões 1 (nome original) e 2 (nome alterado) e a versão atual 3.
+func setupRevisions(t *testing.T) (*gin.Engine, *repository.MemoryStore, *recordedEvents, *models.University) {
+	router, store, events := setupTestRouter(t)
+	ctx := context.Background()
+
+	uni := newTestUniversity("Original University")
+	require.NoError(t, store.Create(ctx, uni))
+	uni.Name = "Renamed University"
+	require.NoError(t, store.Update(ctx, uni))
+	uni.Phone = "(21) 9999-0000"
+	require.NoError(t, store.Update(ctx, uni))
+	return router, store, events, uni
+}
+
+func serveGet(router *gin.Engine, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+	return w
+}
+
+func TestHandler_ListRevisions(t *testing.T) {
+	router, _, _, uni := setupRevisions(t)
+
+	t.Run("Successful List", func(t *testing.T) {
+		w := serveGet(router, "/universities/"+uni.ID.Hex()+"/revisions")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.RevisionListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Data, 2)
+		assert.Equal(t, int64(1), response.Data[0].Revision)
+		assert.Equal(t, models.RevisionUpdated, response.Data[0].Action)
+		assert.Equal(t, "Original University", response.Data[0].Snapshot.Name)
+		assert.Equal(t, int64(2), response.Data[1].Revision)
+		assert.Equal(t, "Renamed University", response.Data[1].Snapshot.Name)
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		w := serveGet(router, "/universities/invalid/revisions")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestHandler_GetRevision(t *testing.T) {
+	router, _, _, uni := setupRevisions(t)
+	base := "/universities/" + uni.ID.Hex() + "/revisions/"
+
+	t.Run("Successful Get", func(t *testing.T) {
+		w := serveGet(router, base+"1")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.RevisionResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, int64(1), response.Data.Revision)
+		assert.Equal(t, "Original University", response.Data.Snapshot.Name)
+	})
+
+	t.Run("Error Paths", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			path   string
+			status int
+		}{
+			{"Invalid Revision", base + "first", http.StatusBadRequest},
+			{"Unknown Revision", base + "9", http.StatusNotFound},
+			{"Invalid ID", "/universities/invalid/revisions/1", http.StatusBadRequest},
+			{"Unknown University", "/universities/" + primitive.NewObjectID().Hex() + "/revisions/1", http.StatusNotFound},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				w := serveGet(router, tt.path)
+				assert.Equal(t, tt.status, w.Code)
+			})
+		}
+	})
+}
+
+func TestHandler_DiffRevisions(t *testing.T) {
+	router, _, _, uni := setupRevisions(t)
+	base := "/universities/" + uni.ID.Hex() + "/revisions/diff"
+
+	diff := func(t *testing.T, query string) models.RevisionDiff {
+		w := serveGet(router, base+query)
+		require.Equal(t, http.StatusOK, w.Code)
+		var response models.RevisionDiffResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data
+	}
+	fields := func(changes []models.FieldChange) []string {
+		names := make([]string, 0, len(changes))
+		for _, change := range changes {
+			names = append(names, change.Field)
+		}
+		return names
+	}
+
+	t.Run("Between Revisions", func(t *testing.T) {
+		result := diff(t, "?from=1&to=2")
+
+		assert.Equal(t, int64(1), result.From)
+		assert.Equal(t, int64(2), result.To)
+		require.Len(t, result.Changes, 1)
+		assert.Equal(t, "name", result.Changes[0].Field)
+		assert.Equal(t, "Original University", result.Changes[0].From)
+		assert.Equal(t, "Renamed University", result.Changes[0].To)
+	})
+
+	t.Run("Against Current Version", func(t *testing.T) {
+		result := diff(t, "?from=1")
+
+		assert.Equal(t, int64(3), result.To)
+		assert.ElementsMatch(t, []string{"name", "phone"}, fields(result.Changes))
+	})
+
+	t.Run("Error Paths", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			path   string
+			status int
+		}{
+			{"Missing From", base, http.StatusBadRequest},
+			{"Invalid To", base + "?from=1&to=last", http.StatusBadRequest},
+			{"Unknown From", base + "?from=9", http.StatusNotFound},
+			{"Unknown To", base + "?from=1&to=9", http.StatusNotFound},
+			{"Unknown University", "/universities/" + primitive.NewObjectID().Hex() + "/revisions/diff?from=1", http.StatusNotFound},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				w := serveGet(router, tt.path)
+				assert.Equal(t, tt.status, w.Code)
+			})
+		}
+	})
+}
+
+func TestHandler_RevertUniversity(t *testing.T) {
+	router, store, events, uni := setupRevisions(t)
+
+	revert := func(id, rev, ifMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/universities/"+id+"/revisions/"+rev+"/revert", nil)
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Error Paths", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			id      string
+			rev     string
+			ifMatch string
+			status  int
+		}{
+			{"Invalid Revision", uni.ID.Hex(), "first", "", http.StatusBadRequest},
+			{"Unknown Revision", uni.ID.Hex(), "9", "", http.StatusNotFound},
+			{"Stale Version", uni.ID.Hex(), "1", `"2"`, http.StatusPreconditionFailed},
+			{"Invalid ID", "invalid", "1", "", http.StatusBadRequest},
+			{"Unknown University", primitive.NewObjectID().Hex(), "1", "", http.StatusNotFound},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				w := revert(tt.id, tt.rev, tt.ifMatch)
+				assert.Equal(t, tt.status, w.Code)
+			})
+		}
+		assert.Empty(t, events.types())
+	})
+
+	t.Run("Successful Revert", func(t *testing.T) {
+		w := revert(uni.ID.Hex(), "1", `"3"`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.UniversityResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "University reverted successfully", response.Message)
+		assert.Equal(t, "Original University", response.Data.Name)
+		assert.Equal(t, "(11) 1234-5678", response.Data.Phone)
+		assert.Equal(t, int64(4), response.Data.Version)
+		assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+
+		saved, err := store.GetByID(context.Background(), uni.ID.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, "Original University", saved.Name)
+		assert.Equal(t, []string{models.EventUniversityReverted}, events.types())
+
+		// O estado substituído vira a revisão 3
+		revision, err := store.GetRevision(context.Background(), uni.ID.Hex(), 3)
+		require.NoError(t, err)
+		assert.Equal(t, models.RevisionReverted, revision.Action)
+		assert.Equal(t, "Renamed University", revision.Snapshot.Name)
+	})
+}
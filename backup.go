@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/university-service/config"
+	"github.com/university-service/internal/repository"
+)
+
+// runBackup grava uma cópia do arquivo do backend bolt em args[0]. Com o
+// serviço em execução, que mantém o arquivo bloqueado, a cópia deve ser obtida
+// em GET /admin/storage/backup. Os erros são devolvidos em vez de encerrar o
+// processo para que o arquivo temporário seja removido.
+func runBackup(store repository.UniversityStore, args []string) error {
+	bolt, ok := store.(*repository.BoltStore)
+	if !ok {
+		return errors.New("backup requires the bolt storage backend")
+	}
+	if len(args) != 1 {
+		return errors.New("usage: backup <file>")
+	}
+
+	// Grava em um arquivo temporário para não deixar uma cópia incompleta
+	path := args[0]
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := bolt.Backup(tmp)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		return err
+	}
+	log.Printf("backup: %d bytes written to %s", written, path)
+	return nil
+}
+
+// runCompact regrava o arquivo do backend bolt sem o espaço livre deixado por
+// alterações e remoções. O serviço precisa estar parado.
+func runCompact(cfg *config.Config) {
+	if cfg.Storage.Backend != "bolt" {
+		log.Fatal("compact requires the bolt storage backend")
+	}
+
+	before, after, err := repository.CompactBoltFile(boltPath(cfg))
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("compact: %d bytes before, %d bytes after", before, after)
+}
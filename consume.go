@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/university-service/config"
+	"github.com/university-service/internal/repository"
+	"github.com/university-service/internal/schema"
+	"github.com/university-service/internal/service"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// runConsumer lê o tópico de eventos e mantém a projeção de leitura na
+// coleção university_projection até receber SIGINT ou SIGTERM.
+func runConsumer(cfg *config.Config, db *mongo.Database) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	projection := repository.NewProjectionRepository(db)
+	if err := projection.EnsureIndexes(ctx, cfg.Consumer.DedupRetention); err != nil {
+		log.Fatal(err)
+	}
+
+	eventFormat, err := service.ParseEventFormat(cfg.Kafka.EventFormat)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// Só registra o schema se os eventos forem publicados em Avro
+	var codec *schema.AvroCodec
+	if eventFormat == service.FormatAvro {
+		if codec, err = newAvroCodec(ctx, cfg); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	security, err := newKafkaSecurity(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	consumer := service.NewProjectionConsumer(cfg.Kafka.Brokers, cfg.Kafka.Topic, cfg.Consumer.GroupID, security,
+		service.NewEventDecoder(codec), projection)
+	defer consumer.Close()
+
+	log.Printf("consuming %s as group %s", cfg.Kafka.Topic, cfg.Consumer.GroupID)
+	if err := consumer.Run(ctx); err != nil {
+		log.Fatal(err)
+	}
+}
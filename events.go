@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/university-service/config"
+	"github.com/university-service/internal/schema"
+	"github.com/university-service/internal/service"
+)
+
+// newEventPublisher cria o publisher do backend escolhido em events.backend.
+// O padrão é o Kafka.
+func newEventPublisher(ctx context.Context, cfg *config.Config) (service.EventPublisher, error) {
+	switch cfg.Events.Backend {
+	case "", service.BackendKafka:
+		return newKafkaService(ctx, cfg)
+	case service.BackendMemory:
+		return service.NewMemoryBus(), nil
+	case service.BackendNATS:
+		return service.NewNATSPublisher(cfg.Events.NATS.URL, cfg.Events.NATS.Subject, cfg.Kafka.Producer)
+	case service.BackendFile:
+		return service.NewFilePublisher(cfg.Events.File)
+	case service.BackendNoop:
+		return service.NoopPublisher{}, nil
+	default:
+		return nil, fmt.Errorf("unknown events backend: %s", cfg.Events.Backend)
+	}
+}
+
+func newKafkaService(ctx context.Context, cfg *config.Config) (*service.KafkaService, error) {
+	eventFormat, err := service.ParseEventFormat(cfg.Kafka.EventFormat)
+	if err != nil {
+		return nil, err
+	}
+	security, err := newKafkaSecurity(cfg)
+	if err != nil {
+		return nil, err
+	}
+	settings := service.ProducerSettings{
+		Compression:     cfg.Kafka.Compression,
+		RequiredAcks:    cfg.Kafka.RequiredAcks,
+		BatchSize:       cfg.Kafka.BatchSize,
+		BatchTimeout:    cfg.Kafka.BatchTimeout,
+		Async:           cfg.Kafka.Async,
+		AutoCreateTopic: cfg.Kafka.AutoCreateTopic,
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+
+	kafkaOptions := []service.KafkaOption{
+		service.WithProducerName(cfg.Kafka.Producer),
+		service.WithEventFormat(eventFormat),
+		service.WithCloudEventsSource(cfg.Kafka.EventSource),
+		service.WithRetryPolicy(service.RetryPolicy{
+			MaxAttempts: cfg.Kafka.Retry.MaxAttempts,
+			Backoff:     cfg.Kafka.Retry.Backoff,
+			MaxBackoff:  cfg.Kafka.Retry.MaxBackoff,
+			Jitter:      cfg.Kafka.Retry.Jitter,
+		}),
+		service.WithDeadLetterTopic(cfg.Kafka.DeadLetterTopic),
+		service.WithSpoolFile(cfg.Kafka.SpoolFile),
+		service.WithSecurity(security),
+		service.WithProducerSettings(settings),
+	}
+	if eventFormat == service.FormatAvro {
+		codec, err := newAvroCodec(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		kafkaOptions = append(kafkaOptions, service.WithAvroCodec(codec))
+	}
+	return service.NewKafkaService(cfg.Kafka.Brokers, cfg.Kafka.Topic, kafkaOptions...), nil
+}
+
+func newKafkaSecurity(cfg *config.Config) (service.KafkaSecurity, error) {
+	var security service.KafkaSecurity
+	if cfg.Kafka.TLS.Enabled {
+		tlsConfig, err := service.LoadTLSConfig(cfg.Kafka.TLS.CAFile, cfg.Kafka.TLS.CertFile, cfg.Kafka.TLS.KeyFile, cfg.Kafka.TLS.InsecureSkipVerify)
+		if err != nil {
+			return security, err
+		}
+		security.TLS = tlsConfig
+	}
+
+	mechanism, err := service.NewSASLMechanism(cfg.Kafka.SASL.Mechanism, cfg.Kafka.SASL.Username, cfg.Kafka.SASL.Password)
+	if err != nil {
+		return security, err
+	}
+	security.SASL = mechanism
+	return security, nil
+}
+
+func newAvroCodec(ctx context.Context, cfg *config.Config) (*schema.AvroCodec, error) {
+	registry, err := schema.NewFileRegistry(cfg.Kafka.SchemaRegistry)
+	if err != nil {
+		return nil, err
+	}
+	return schema.NewAvroCodec(ctx, registry, cfg.Kafka.Topic+"-value")
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// Origem de um evento que não pôde ser publicado
+const (
+	DeadLetterTopic = "topic"
+	DeadLetterSpool = "spool"
+)
+
+// DeadLetter é um evento que esgotou as tentativas de publicação e foi
+// desviado para o tópico de dead letters ou, com o Kafka fora do ar, para o
+// arquivo de spool local.
+type DeadLetter struct {
+	Event     *UniversityEvent `json:"event"`
+	Error     string           `json:"error"`
+	Attempts  int              `json:"attempts"`
+	FailedAt  time.Time        `json:"failed_at"`
+	Source    string           `json:"source"`
+	Partition int              `json:"partition,omitempty"`
+	Offset    int64            `json:"offset,omitempty"`
+}
+
+type DeadLetterListResponse struct {
+	Status  int          `json:"status"`
+	Message string       `json:"message"`
+	Data    []DeadLetter `json:"data"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Campos controlados pelo serviço, que não contam como alteração do usuário
+var systemFields = map[string]bool{
+	"id":         true,
+	"created_at": true,
+	"updated_at": true,
+	"version":    true,
+	"deleted_at": true,
+}
+
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// Diff compara duas versões de uma universidade e retorna os campos cujo valor
+// mudou, identificados pela tag json.
+func Diff(before, after *University) []FieldChange {
+	beforeValue := reflect.ValueOf(before).Elem()
+	afterValue := reflect.ValueOf(after).Elem()
+	universityType := beforeValue.Type()
+
+	changes := make([]FieldChange, 0)
+	for i := 0; i < universityType.NumField(); i++ {
+		name := jsonFieldName(universityType.Field(i))
+		if name == "" || systemFields[name] {
+			continue
+		}
+		from := beforeValue.Field(i).Interface()
+		to := afterValue.Field(i).Interface()
+		if !reflect.DeepEqual(from, to) {
+			changes = append(changes, FieldChange{Field: name, From: from, To: to})
+		}
+	}
+	return changes
+}
+
+// ChangedFields retorna apenas os nomes dos campos alterados entre as versões.
+func ChangedFields(before, after *University) []string {
+	var changed []string
+	for _, change := range Diff(before, after) {
+		changed = append(changed, change.Field)
+	}
+	return changed
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestChangedFields(t *testing.T) {
+	before := &University{
+		ID:        primitive.NewObjectID(),
+		Name:      "Test University",
+		Address:   "123 Test St",
+		Phone:     "(11) 1234-5678",
+		Email:     "test@university.edu",
+		Website:   "https://test.edu",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	after := *before
+	after.Phone = "(11) 8765-4321"
+	after.Email = "new@university.edu"
+	after.UpdatedAt = time.Now().Add(time.Hour)
+	after.NameNormalized = "ignored"
+
+	changed := ChangedFields(before, &after)
+	if len(changed) != 2 || changed[0] != "phone" || changed[1] != "email" {
+		t.Errorf("Expected [phone email], got %v", changed)
+	}
+
+	if changed := ChangedFields(before, before); len(changed) != 0 {
+		t.Errorf("Expected no changes, got %v", changed)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	before := &University{Name: "Old Name", Email: "old@test.edu", Version: 1}
+	after := &University{Name: "Old Name", Email: "new@test.edu", Version: 2}
+
+	changes := Diff(before, after)
+	if len(changes) != 1 {
+		t.Fatalf("Expected 1 change, got %v", changes)
+	}
+	if changes[0].Field != "email" || changes[0].From != "old@test.edu" || changes[0].To != "new@test.edu" {
+		t.Errorf("Unexpected change: %+v", changes[0])
+	}
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	EventUniversityCreated  = "university_created"
+	EventUniversityUpdated  = "university_updated"
+	EventUniversityPatched  = "university_patched"
+	EventUniversityDeleted  = "university_deleted"
+	EventUniversityRestored = "university_restored"
+	EventUniversityPurged   = "university_purged"
+	EventUniversityReverted = "university_reverted"
+	// Estado atual de uma universidade, republicado pelo reindex sem que ela
+	// tenha sido alterada
+	EventUniversitySnapshot = "university_snapshot"
+)
+
+// EventSchemaVersion é a versão atual do formato de UniversityEvent. Deve ser
+// incrementada sempre que uma mudança incompatível for feita no payload, junto
+// com o schema Avro: o valor acompanha o N de
+// internal/schema/avro/university_event.vN.avsc embutido pelo codec.
+const EventSchemaVersion = 2
+
+type UniversityEvent struct {
+	ID            string      `bson:"id" json:"id"`
+	Type          string      `bson:"type" json:"type"`
+	SchemaVersion int         `bson:"schema_version" json:"schema_version"`
+	OccurredAt    time.Time   `bson:"occurred_at" json:"occurred_at"`
+	CorrelationID string      `bson:"correlation_id,omitempty" json:"correlation_id,omitempty"`
+	University    *University `bson:"university" json:"university"`
+	ChangedFields []string    `bson:"changed_fields,omitempty" json:"changed_fields,omitempty"`
+	// Estado da universidade antes e depois da alteração, nos eventos que a modificam
+	Before *University `bson:"before,omitempty" json:"before,omitempty"`
+	After  *University `bson:"after,omitempty" json:"after,omitempty"`
+}
+
+// NewUniversityEvent cria um evento com identificador único, usado pelos
+// consumidores para descartar duplicatas.
+func NewUniversityEvent(eventType string, university *University) *UniversityEvent {
+	return &UniversityEvent{
+		ID:            uuid.NewString(),
+		Type:          eventType,
+		SchemaVersion: EventSchemaVersion,
+		OccurredAt:    time.Now().UTC(),
+		University:    university,
+	}
+}
+
+// Key retorna a chave de particionamento do evento: o ID da universidade, para
+// que os eventos de uma mesma universidade fiquem na mesma partição e em ordem.
+func (e *UniversityEvent) Key() string {
+	if e.University == nil || e.University.ID.IsZero() {
+		return ""
+	}
+	return e.University.ID.Hex()
+}
+
+// SetChanges registra no evento os estados anterior e posterior da
+// universidade e os campos que mudaram entre eles.
+func (e *UniversityEvent) SetChanges(before, after *University) {
+	e.Before = before
+	e.After = after
+	e.ChangedFields = ChangedFields(before, after)
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestUniversityEvent_SetChanges(t *testing.T) {
+	before := &University{ID: primitive.NewObjectID(), Name: "Old Name", Phone: "(11) 1234-5678"}
+	after := *before
+	after.Name = "New Name"
+
+	event := NewUniversityEvent(EventUniversityUpdated, &after)
+	event.SetChanges(before, &after)
+
+	if event.Before.Name != "Old Name" || event.After.Name != "New Name" {
+		t.Errorf("Expected before/after snapshots, got %v and %v", event.Before.Name, event.After.Name)
+	}
+	if len(event.ChangedFields) != 1 || event.ChangedFields[0] != "name" {
+		t.Errorf("Expected [name], got %v", event.ChangedFields)
+	}
+}
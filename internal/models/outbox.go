@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	OutboxPending   = "pending"
+	OutboxDelivered = "delivered"
+	OutboxFailed    = "failed"
+)
+
+// OutboxRecord é um evento gravado na mesma transação da alteração que o
+// originou, aguardando (ou já concluída) a publicação no Kafka.
+type OutboxRecord struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Event         UniversityEvent    `bson:"event" json:"event"`
+	Status        string             `bson:"status" json:"status"`
+	Attempts      int                `bson:"attempts" json:"attempts"`
+	LastError     string             `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	CreatedAt     time.Time          `bson:"created_at" json:"created_at"`
+	NextAttemptAt time.Time          `bson:"next_attempt_at" json:"next_attempt_at"`
+	ClaimedUntil  *time.Time         `bson:"claimed_until,omitempty" json:"-"`
+	DeliveredAt   *time.Time         `bson:"delivered_at,omitempty" json:"delivered_at,omitempty"`
+}
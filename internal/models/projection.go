@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Situação da universidade no modelo de leitura
+const (
+	ProjectionActive  = "active"
+	ProjectionDeleted = "deleted"
+	ProjectionPurged  = "purged"
+)
+
+// UniversityProjection é o modelo de leitura mantido pelo consumidor de
+// eventos: o último estado conhecido de cada universidade e quantos eventos de
+// cada tipo foram recebidos para ela.
+type UniversityProjection struct {
+	ID            primitive.ObjectID `bson:"_id" json:"id"`
+	University    *University        `bson:"university,omitempty" json:"university,omitempty"`
+	Status        string             `bson:"status" json:"status"`
+	EventCounts   map[string]int64   `bson:"event_counts" json:"event_counts"`
+	TotalEvents   int64              `bson:"total_events" json:"total_events"`
+	LastEventID   string             `bson:"last_event_id" json:"last_event_id"`
+	LastEventType string             `bson:"last_event_type" json:"last_event_type"`
+	LastEventAt   time.Time          `bson:"last_event_at" json:"last_event_at"`
+	UpdatedAt     time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// ProjectionStatus retorna a situação da universidade após o evento.
+func ProjectionStatus(event *UniversityEvent) string {
+	switch {
+	case event.Type == EventUniversityPurged:
+		return ProjectionPurged
+	case event.University != nil && event.University.DeletedAt != nil:
+		return ProjectionDeleted
+	default:
+		return ProjectionActive
+	}
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Ações que substituem uma versão da universidade e geram uma revisão
+const (
+	RevisionUpdated  = "updated"
+	RevisionPatched  = "patched"
+	RevisionDeleted  = "deleted"
+	RevisionRestored = "restored"
+	RevisionPurged   = "purged"
+	RevisionReverted = "reverted"
+)
+
+// Revision guarda uma versão anterior de uma universidade. O número da
+// revisão é a versão do snapshot; Action, ChangedBy e ChangedAt descrevem a
+// alteração que substituiu essa versão.
+type Revision struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"-"`
+	UniversityID primitive.ObjectID `bson:"university_id" json:"university_id"`
+	Revision     int64              `bson:"revision" json:"revision"`
+	Action       string             `bson:"action" json:"action"`
+	ChangedBy    string             `bson:"changed_by,omitempty" json:"changed_by,omitempty"`
+	ChangedAt    time.Time          `bson:"changed_at" json:"changed_at"`
+	Snapshot     University         `bson:"snapshot" json:"snapshot"`
+}
+
+type RevisionResponse struct {
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Data    Revision `json:"data"`
+}
+
+type RevisionListResponse struct {
+	Status  int        `json:"status"`
+	Message string     `json:"message"`
+	Data    []Revision `json:"data"`
+}
+
+type RevisionDiff struct {
+	UniversityID primitive.ObjectID `json:"university_id"`
+	From         int64              `json:"from"`
+	To           int64              `json:"to"`
+	Changes      []FieldChange      `json:"changes"`
+}
+
+type RevisionDiffResponse struct {
+	Status  int          `json:"status"`
+	Message string       `json:"message"`
+	Data    RevisionDiff `json:"data"`
+}
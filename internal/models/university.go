@@ -7,18 +7,67 @@ import (
 )
 
 type University struct {
-	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
-	Name        string            `bson:"name" json:"name" binding:"required"`
-	Address     string            `bson:"address" json:"address" binding:"required"`
-	Phone       string            `bson:"phone" json:"phone" binding:"required"`
-	Email       string            `bson:"email" json:"email" binding:"required,email"`
-	Website     string            `bson:"website" json:"website"`
-	CreatedAt   time.Time         `bson:"created_at" json:"created_at"`
-	UpdatedAt   time.Time         `bson:"updated_at" json:"updated_at"`
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Name           string             `bson:"name" json:"name" binding:"required"`
+	Address        string             `bson:"address" json:"address" binding:"required"`
+	Phone          string             `bson:"phone" json:"phone" binding:"required"`
+	Email          string             `bson:"email" json:"email" binding:"required,email"`
+	Website        string             `bson:"website" json:"website"`
+	CreatedAt      time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time          `bson:"updated_at" json:"updated_at"`
+	Version        int64              `bson:"version" json:"version"`
+	DeletedAt      *time.Time         `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	NameNormalized string             `bson:"name_normalized" json:"-"`
 }
 
 type UniversityResponse struct {
 	Status  int        `json:"status"`
 	Message string     `json:"message"`
 	Data    University `json:"data"`
-}
\ No newline at end of file
+}
+
+type Pagination struct {
+	Page       int64 `json:"page"`
+	PageSize   int64 `json:"page_size"`
+	Offset     int64 `json:"offset"`
+	Total      int64 `json:"total"`
+	TotalPages int64 `json:"total_pages"`
+}
+
+type Links struct {
+	Self string `json:"self"`
+	Next string `json:"next,omitempty"`
+	Prev string `json:"prev,omitempty"`
+}
+
+type UniversityListResponse struct {
+	Status     int          `json:"status"`
+	Message    string       `json:"message"`
+	Data       []University `json:"data"`
+	Pagination *Pagination  `json:"pagination,omitempty"`
+	Links      Links        `json:"links"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
+
+type SearchHit struct {
+	University University        `json:"university"`
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights,omitempty"`
+}
+
+type SearchResponse struct {
+	Status  int         `json:"status"`
+	Message string      `json:"message"`
+	Data    []SearchHit `json:"data"`
+}
+
+type Suggestion struct {
+	ID   primitive.ObjectID `bson:"_id" json:"id"`
+	Name string             `bson:"name" json:"name"`
+}
+
+type SuggestionResponse struct {
+	Status  int          `json:"status"`
+	Message string       `json:"message"`
+	Data    []Suggestion `json:"data"`
+}
@@ -0,0 +1,396 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/university-service/internal/models"
+	"github.com/university-service/internal/textutil"
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Buckets do arquivo do BoltStore. As universidades são indexadas pelos 12
+// bytes do ObjectID, que ordenam como o _id do MongoDB; as revisões, pelo
+// ObjectID da universidade seguido do número da revisão.
+var (
+	boltUniversities = []byte("universities")
+	boltRevisions    = []byte("revisions")
+)
+
+// boltOpenTimeout limita a espera pelo lock do arquivo, que fica com o
+// processo que o abriu primeiro.
+const boltOpenTimeout = 5 * time.Second
+
+// BoltStore é uma implementação de UniversityStore em um arquivo bbolt, para
+// instalações sem MongoDB nem PostgreSQL. Os registros são gravados em BSON;
+// as consultas percorrem o bucket inteiro, o que atende bem às bases pequenas
+// a que ele se destina.
+type BoltStore struct {
+	versionedWrites
+	scanQueries
+
+	db *bolt.DB
+}
+
+// OpenBoltStore abre, ou cria, o arquivo em path. Apenas um processo pode
+// mantê-lo aberto por vez.
+func OpenBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: boltOpenTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{boltUniversities, boltRevisions} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	s := &BoltStore{db: db}
+	s.versionedWrites = versionedWrites{update: s.update}
+	s.scanQueries = scanQueries{find: s.find}
+	return s, nil
+}
+
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+// Backup grava em w uma cópia consistente do arquivo, sem bloquear as
+// gravações feitas enquanto ela é gerada.
+func (s *BoltStore) Backup(w io.Writer) (int64, error) {
+	var written int64
+	err := s.db.View(func(tx *bolt.Tx) error {
+		var err error
+		written, err = tx.WriteTo(w)
+		return err
+	})
+	return written, err
+}
+
+// CompactBoltFile regrava o arquivo em path sem as páginas livres deixadas por
+// registros alterados e removidos. O arquivo não pode estar aberto por outro
+// processo; o original só é substituído se a cópia for concluída.
+func CompactBoltFile(path string) (before, after int64, err error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	src, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: boltOpenTimeout, ReadOnly: true})
+	if err != nil {
+		return 0, 0, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer src.Close()
+
+	// Restos de uma compactação interrompida são descartados
+	tmp := path + ".compact"
+	if err := os.Remove(tmp); err != nil && !os.IsNotExist(err) {
+		return 0, 0, err
+	}
+	dst, err := bolt.Open(tmp, info.Mode().Perm(), &bolt.Options{Timeout: boltOpenTimeout})
+	if err != nil {
+		return 0, 0, fmt.Errorf("open %s: %w", tmp, err)
+	}
+	if err := bolt.Compact(dst, src, 0); err != nil {
+		dst.Close()
+		os.Remove(tmp)
+		return 0, 0, err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(tmp)
+		return 0, 0, err
+	}
+
+	compacted, err := os.Stat(tmp)
+	if err != nil {
+		return 0, 0, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return 0, 0, err
+	}
+	return info.Size(), compacted.Size(), nil
+}
+
+type boltTxKey struct{}
+
+// view executa fn na transação aberta por WithTransaction ou, sem ela, em uma
+// transação de leitura. O bbolt não permite abrir outra transação na mesma
+// goroutine enquanto a de escrita estiver aberta.
+func (s *BoltStore) view(ctx context.Context, fn func(tx *bolt.Tx) error) error {
+	if tx, ok := ctx.Value(boltTxKey{}).(*bolt.Tx); ok {
+		return fn(tx)
+	}
+	return boltError(s.db.View(fn))
+}
+
+func (s *BoltStore) write(ctx context.Context, fn func(tx *bolt.Tx) error) error {
+	if tx, ok := ctx.Value(boltTxKey{}).(*bolt.Tx); ok {
+		return fn(tx)
+	}
+	return boltError(s.db.Update(fn))
+}
+
+// boltError trata o arquivo fechado como ErrUnavailable; os demais erros já
+// são os de errors.go ou não têm equivalente.
+func boltError(err error) error {
+	if errors.Is(err, bolt.ErrDatabaseNotOpen) {
+		return fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+	return err
+}
+
+// WithTransaction executa fn em uma transação de escrita. Transações
+// aninhadas usam a de fora, e um erro em qualquer uma desfaz tudo.
+func (s *BoltStore) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(boltTxKey{}).(*bolt.Tx); ok {
+		return fn(ctx)
+	}
+	return boltError(s.db.Update(func(tx *bolt.Tx) error {
+		return fn(context.WithValue(ctx, boltTxKey{}, tx))
+	}))
+}
+
+func getUniversity(tx *bolt.Tx, id primitive.ObjectID) (models.University, error) {
+	var university models.University
+	data := tx.Bucket(boltUniversities).Get(id[:])
+	if data == nil {
+		return university, ErrNotFound
+	}
+	err := bson.Unmarshal(data, &university)
+	return university, err
+}
+
+func putUniversity(tx *bolt.Tx, university models.University) error {
+	data, err := bson.Marshal(stored(university))
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(boltUniversities).Put(university.ID[:], data)
+}
+
+func (s *BoltStore) Create(ctx context.Context, university *models.University) error {
+	id := university.ID
+	if id.IsZero() {
+		id = primitive.NewObjectID()
+	}
+
+	university.CreatedAt = time.Now()
+	university.UpdatedAt = time.Now()
+	university.NameNormalized = textutil.Fold(university.Name)
+	university.Version = 1
+
+	err := s.write(ctx, func(tx *bolt.Tx) error {
+		if tx.Bucket(boltUniversities).Get(id[:]) != nil {
+			return fmt.Errorf("%w: %s", ErrDuplicate, id.Hex())
+		}
+		created := *university
+		created.ID = id
+		return putUniversity(tx, created)
+	})
+	if err != nil {
+		return err
+	}
+
+	university.ID = id
+	return nil
+}
+
+func (s *BoltStore) GetByID(ctx context.Context, id string) (*models.University, error) {
+	return s.getByID(ctx, id, false)
+}
+
+func (s *BoltStore) GetByIDIncludingDeleted(ctx context.Context, id string) (*models.University, error) {
+	return s.getByID(ctx, id, true)
+}
+
+func (s *BoltStore) getByID(ctx context.Context, id string, includeDeleted bool) (*models.University, error) {
+	objectID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var university models.University
+	err = s.view(ctx, func(tx *bolt.Tx) error {
+		university, err = getUniversity(tx, objectID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !includeDeleted && university.DeletedAt != nil {
+		return nil, ErrNotFound
+	}
+	return &university, nil
+}
+
+// update roda em uma transação de escrita do bbolt, que já é exclusiva: das
+// atualizações concorrentes da mesma versão, apenas a primeira a encontra.
+func (s *BoltStore) update(ctx context.Context, id primitive.ObjectID, expectedVersion int64, includeDeleted bool, action string, apply func(models.University) (models.University, error)) error {
+	return s.write(ctx, func(tx *bolt.Tx) error {
+		current, err := getUniversity(tx, id)
+		if err != nil {
+			return err
+		}
+		if !includeDeleted && current.DeletedAt != nil {
+			return ErrNotFound
+		}
+		if current.Version != expectedVersion {
+			return ErrVersionConflict
+		}
+
+		updated, err := apply(current)
+		if err != nil {
+			return err
+		}
+		updated.ID = id
+		updated.NameNormalized = textutil.Fold(updated.Name)
+
+		if err := putUniversity(tx, updated); err != nil {
+			return err
+		}
+		return recordBoltRevision(ctx, tx, current, action)
+	})
+}
+
+// revisionKey ordena as revisões de cada universidade pelo número.
+func revisionKey(universityID primitive.ObjectID, revision int64) []byte {
+	key := make([]byte, len(universityID)+8)
+	copy(key, universityID[:])
+	binary.BigEndian.PutUint64(key[len(universityID):], uint64(revision))
+	return key
+}
+
+func recordBoltRevision(ctx context.Context, tx *bolt.Tx, previous models.University, action string) error {
+	data, err := bson.Marshal(models.Revision{
+		ID:           primitive.NewObjectID(),
+		UniversityID: previous.ID,
+		Revision:     previous.Version,
+		Action:       action,
+		ChangedBy:    actorFrom(ctx),
+		ChangedAt:    bsonNow(),
+		Snapshot:     previous,
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(boltRevisions).Put(revisionKey(previous.ID, previous.Version), data)
+}
+
+func (s *BoltStore) Purge(ctx context.Context, deletedBefore time.Time) ([]models.University, error) {
+	purged := make([]models.University, 0)
+	err := s.write(ctx, func(tx *bolt.Tx) error {
+		universities, err := scanUniversitiesBolt(tx, func(u models.University) bool {
+			return u.DeletedAt != nil && u.DeletedAt.Before(deletedBefore)
+		})
+		if err != nil {
+			return err
+		}
+
+		bucket := tx.Bucket(boltUniversities)
+		for _, university := range universities {
+			if err := bucket.Delete(university.ID[:]); err != nil {
+				return err
+			}
+			if err := recordBoltRevision(ctx, tx, university, models.RevisionPurged); err != nil {
+				return err
+			}
+		}
+		purged = universities
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return purged, nil
+}
+
+func (s *BoltStore) ListRevisions(ctx context.Context, id string) ([]models.Revision, error) {
+	objectID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]models.Revision, 0)
+	err = s.view(ctx, func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(boltRevisions).Cursor()
+		prefix := objectID[:]
+		for key, data := cursor.Seek(prefix); key != nil && bytes.HasPrefix(key, prefix); key, data = cursor.Next() {
+			var revision models.Revision
+			if err := bson.Unmarshal(data, &revision); err != nil {
+				return err
+			}
+			revisions = append(revisions, revision)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+func (s *BoltStore) GetRevision(ctx context.Context, id string, revision int64) (*models.Revision, error) {
+	objectID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var found models.Revision
+	err = s.view(ctx, func(tx *bolt.Tx) error {
+		data := tx.Bucket(boltRevisions).Get(revisionKey(objectID, revision))
+		if data == nil {
+			return ErrNotFound
+		}
+		return bson.Unmarshal(data, &found)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &found, nil
+}
+
+// scanUniversitiesBolt retorna, em ordem de _id, os registros aceitos por
+// match.
+func scanUniversitiesBolt(tx *bolt.Tx, match func(models.University) bool) ([]models.University, error) {
+	universities := make([]models.University, 0)
+	err := tx.Bucket(boltUniversities).ForEach(func(_, data []byte) error {
+		var university models.University
+		if err := bson.Unmarshal(data, &university); err != nil {
+			return err
+		}
+		if match(university) {
+			universities = append(universities, university)
+		}
+		return nil
+	})
+	return universities, err
+}
+
+func (s *BoltStore) find(ctx context.Context, opts ListOptions) ([]models.University, error) {
+	var universities []models.University
+	err := s.view(ctx, func(tx *bolt.Tx) error {
+		var err error
+		universities, err = scanUniversitiesBolt(tx, func(u models.University) bool {
+			return matchesListOptions(u, opts)
+		})
+		return err
+	})
+	return universities, err
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/university-service/internal/models"
+)
+
+func openTestBolt(t *testing.T, path string) *BoltStore {
+	store, err := OpenBoltStore(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestBoltStore_Contract(t *testing.T) {
+	testUniversityStore(t, func(t *testing.T) UniversityStore {
+		return openTestBolt(t, filepath.Join(t.TempDir(), "universities.db"))
+	})
+}
+
+func TestBoltStore_Reopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "universities.db")
+
+	store, err := OpenBoltStore(path)
+	require.NoError(t, err)
+	uni := &models.University{Name: "Unicamp", Address: "Campinas"}
+	require.NoError(t, store.Create(ctx, uni))
+	require.NoError(t, store.Delete(ctx, uni))
+	require.NoError(t, store.Close())
+
+	store = openTestBolt(t, path)
+	found, err := store.GetByIDIncludingDeleted(ctx, uni.ID.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, "Unicamp", found.Name)
+	assert.NotNil(t, found.DeletedAt)
+
+	revisions, err := store.ListRevisions(ctx, uni.ID.Hex())
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	assert.Equal(t, models.RevisionDeleted, revisions[0].Action)
+}
+
+func TestBoltStore_WithTransaction(t *testing.T) {
+	ctx := context.Background()
+	store := openTestBolt(t, filepath.Join(t.TempDir(), "universities.db"))
+
+	uni := &models.University{Name: "Unicamp"}
+	err := store.WithTransaction(ctx, func(ctx context.Context) error {
+		require.NoError(t, store.Create(ctx, uni))
+		_, err := store.GetByID(ctx, uni.ID.Hex())
+		require.NoError(t, err)
+		return assert.AnError
+	})
+	assert.ErrorIs(t, err, assert.AnError)
+
+	// A criação foi desfeita junto com a transação
+	universities, total, err := store.List(ctx, ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, universities)
+	assert.Zero(t, total)
+}
+
+func TestBoltStore_BackupAndCompact(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "universities.db")
+
+	store, err := OpenBoltStore(path)
+	require.NoError(t, err)
+	var kept *models.University
+	for i := 0; i < 200; i++ {
+		uni := &models.University{Name: "Universidade", Address: "Rua Teste"}
+		require.NoError(t, store.Create(ctx, uni))
+		if i == 0 {
+			kept = uni
+			continue
+		}
+		require.NoError(t, store.Delete(ctx, uni))
+	}
+	_, err = store.Purge(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	var backup bytes.Buffer
+	written, err := store.Backup(&backup)
+	require.NoError(t, err)
+	assert.Equal(t, int64(backup.Len()), written)
+	require.NoError(t, store.Close())
+
+	before, after, err := CompactBoltFile(path)
+	require.NoError(t, err)
+	assert.Less(t, after, before)
+
+	// A cópia e o arquivo compactado têm os mesmos dados
+	backupPath := filepath.Join(dir, "backup.db")
+	require.NoError(t, os.WriteFile(backupPath, backup.Bytes(), 0o600))
+	for _, p := range []string{path, backupPath} {
+		store := openTestBolt(t, p)
+		found, err := store.GetByID(ctx, kept.ID.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, kept.Name, found.Name)
+
+		_, total, err := store.List(ctx, ListOptions{IncludeDeleted: true})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+	}
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/university-service/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// pageCursor identifica o último documento entregue ao cliente. É serializado
+// em base64 para que o formato continue opaco para quem consome a API.
+type pageCursor struct {
+	CreatedAt time.Time          `json:"c"`
+	ID        primitive.ObjectID `json:"i"`
+}
+
+func encodeCursor(university models.University) string {
+	data, _ := json.Marshal(pageCursor{CreatedAt: university.CreatedAt, ID: university.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(value string) (*pageCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor pageCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// ListByCursor percorre a coleção em ordem de (created_at, _id) a partir do
+// cursor informado. Diferente da paginação por offset, inserções e remoções
+// concorrentes não fazem registros serem pulados ou repetidos. Um cursor vazio
+// começa do início; o cursor retornado fica vazio quando não há mais páginas.
+func (r *UniversityRepository) ListByCursor(ctx context.Context, opts ListOptions, cursor string) ([]models.University, string, error) {
+	filter := buildListFilter(opts)
+
+	if cursor != "" {
+		after, err := decodeCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		filter["$or"] = bson.A{
+			bson.M{"created_at": bson.M{"$gt": after.CreatedAt}},
+			bson.M{"created_at": after.CreatedAt, "_id": bson.M{"$gt": after.ID}},
+		}
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+
+	// Busca um registro extra apenas para saber se existe próxima página
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(limit + 1)
+
+	result, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, "", mongoError(err)
+	}
+	defer result.Close(ctx)
+
+	universities := make([]models.University, 0)
+	if err = result.All(ctx, &universities); err != nil {
+		return nil, "", mongoError(err)
+	}
+
+	var nextCursor string
+	if int64(len(universities)) > limit {
+		universities = universities[:limit]
+		nextCursor = encodeCursor(universities[len(universities)-1])
+	}
+
+	return universities, nextCursor, nil
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/university-service/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	uni := models.University{
+		ID:        primitive.NewObjectID(),
+		CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+
+	cursor, err := decodeCursor(encodeCursor(uni))
+	assert.NoError(t, err)
+	assert.Equal(t, uni.ID, cursor.ID)
+	assert.True(t, uni.CreatedAt.Equal(cursor.CreatedAt))
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	for _, value := range []string{"not base64!", "bm90IGpzb24", "e30"} {
+		_, err := decodeCursor(value)
+		assert.ErrorIs(t, err, ErrInvalidCursor, value)
+	}
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Erros retornados por todas as implementações de UniversityStore, no lugar
+// dos erros dos drivers. Os demais detalhes continuam disponíveis pela cadeia
+// de errors.Unwrap, mas não devem ser repassados aos clientes da API.
+var (
+	// ErrNotFound indica que o registro não existe (ou está excluído
+	// logicamente, nas buscas que ignoram os excluídos).
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidID indica um ID que não está no formato ObjectID.
+	ErrInvalidID = errors.New("invalid id")
+	// ErrConflict indica que a gravação não pôde ser feita no estado atual do
+	// registro.
+	ErrConflict = errors.New("conflict")
+	// ErrDuplicate indica que já existe um registro com a mesma chave.
+	ErrDuplicate = errors.New("duplicate key")
+	// ErrUnavailable indica que o armazenamento não respondeu, por falha de
+	// rede ou tempo esgotado; a operação pode ser repetida.
+	ErrUnavailable = errors.New("storage unavailable")
+)
+
+// ErrVersionConflict indica que o documento foi alterado desde a versão lida
+// pelo chamador. É um caso de ErrConflict.
+var ErrVersionConflict = fmt.Errorf("version %w", ErrConflict)
+
+// parseID converte o ID recebido da API, retornando ErrInvalidID se ele não
+// for um ObjectID.
+func parseID(id string) (primitive.ObjectID, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return objectID, fmt.Errorf("%w: %q", ErrInvalidID, id)
+	}
+	return objectID, nil
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/university-service/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	DefaultPageSize int64 = 20
+	MaxPageSize     int64 = 100
+)
+
+// Campos aceitos no parâmetro sort (nome na API -> nome no documento)
+var sortableFields = map[string]string{
+	"name":       "name",
+	"email":      "email",
+	"website":    "website",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+type ListOptions struct {
+	Offset      int64
+	Limit       int64
+	Name        string
+	EmailDomain string
+	Website     string
+	Sort        []SortField
+	// IncludeDeleted inclui no resultado as universidades excluídas logicamente
+	IncludeDeleted bool
+}
+
+// ParseSort interpreta valores como "name,-created_at", onde o prefixo "-"
+// indica ordem decrescente.
+func ParseSort(value string) ([]SortField, error) {
+	var fields []SortField
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		field := SortField{Field: part}
+		switch {
+		case strings.HasPrefix(part, "-"):
+			field = SortField{Field: part[1:], Desc: true}
+		case strings.HasPrefix(part, "+"):
+			field = SortField{Field: part[1:]}
+		}
+
+		if _, ok := sortableFields[field.Field]; !ok {
+			return nil, fmt.Errorf("invalid sort field: %s", field.Field)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+func buildListFilter(opts ListOptions) bson.M {
+	filter := bson.M{}
+	if !opts.IncludeDeleted {
+		filter["deleted_at"] = nil
+	}
+	if opts.Name != "" {
+		filter["name"] = bson.M{"$regex": regexp.QuoteMeta(opts.Name), "$options": "i"}
+	}
+	if opts.EmailDomain != "" {
+		domain := strings.TrimPrefix(opts.EmailDomain, "@")
+		filter["email"] = bson.M{"$regex": "@" + regexp.QuoteMeta(domain) + "$", "$options": "i"}
+	}
+	if opts.Website != "" {
+		filter["website"] = bson.M{"$regex": regexp.QuoteMeta(opts.Website), "$options": "i"}
+	}
+	return filter
+}
+
+func buildSort(fields []SortField) bson.D {
+	sort := bson.D{}
+	for _, f := range fields {
+		direction := 1
+		if f.Desc {
+			direction = -1
+		}
+		sort = append(sort, bson.E{Key: sortableFields[f.Field], Value: direction})
+	}
+	// _id como critério de desempate garante uma ordem estável entre as páginas
+	return append(sort, bson.E{Key: "_id", Value: 1})
+}
+
+func (r *UniversityRepository) List(ctx context.Context, opts ListOptions) ([]models.University, int64, error) {
+	filter := buildListFilter(opts)
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, mongoError(err)
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+
+	findOptions := options.Find().
+		SetSort(buildSort(opts.Sort)).
+		SetSkip(opts.Offset).
+		SetLimit(limit)
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, mongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	universities := make([]models.University, 0)
+	if err = cursor.All(ctx, &universities); err != nil {
+		return nil, 0, mongoError(err)
+	}
+
+	return universities, total, nil
+}
+
+// Each chama fn para cada universidade que atende aos filtros de opts, em
+// ordem de _id, sem carregar a coleção inteira na memória. Offset e Sort são
+// ignorados; Limit, se maior que zero, limita o total de documentos.
+func (r *UniversityRepository) Each(ctx context.Context, opts ListOptions, fn func(*models.University) error) error {
+	findOptions := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	if opts.Limit > 0 {
+		findOptions.SetLimit(opts.Limit)
+	}
+
+	cursor, err := r.collection.Find(ctx, buildListFilter(opts), findOptions)
+	if err != nil {
+		return mongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var university models.University
+		if err := cursor.Decode(&university); err != nil {
+			return mongoError(err)
+		}
+		if err := fn(&university); err != nil {
+			return err
+		}
+	}
+	return mongoError(cursor.Err())
+}
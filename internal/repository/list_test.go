@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestParseSort(t *testing.T) {
+	t.Run("Multiple Fields", func(t *testing.T) {
+		fields, err := ParseSort("name,-created_at,+email")
+		assert.NoError(t, err)
+		assert.Equal(t, []SortField{
+			{Field: "name"},
+			{Field: "created_at", Desc: true},
+			{Field: "email"},
+		}, fields)
+	})
+
+	t.Run("Invalid Field", func(t *testing.T) {
+		_, err := ParseSort("phone")
+		assert.Error(t, err)
+	})
+}
+
+func TestBuildListFilter(t *testing.T) {
+	filter := buildListFilter(ListOptions{
+		Name:        "São Paulo",
+		EmailDomain: "@usp.br",
+		Website:     "usp",
+	})
+
+	assert.Equal(t, bson.M{"$regex": "São Paulo", "$options": "i"}, filter["name"])
+	assert.Equal(t, bson.M{"$regex": `@usp\.br$`, "$options": "i"}, filter["email"])
+	assert.Equal(t, bson.M{"$regex": "usp", "$options": "i"}, filter["website"])
+
+	assert.Equal(t, bson.M{"deleted_at": nil}, buildListFilter(ListOptions{}))
+	assert.Empty(t, buildListFilter(ListOptions{IncludeDeleted: true}))
+}
+
+func TestBuildSort(t *testing.T) {
+	sort := buildSort([]SortField{{Field: "name", Desc: true}})
+	assert.Equal(t, bson.D{{Key: "name", Value: -1}, {Key: "_id", Value: 1}}, sort)
+}
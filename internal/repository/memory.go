@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/university-service/internal/models"
+	"github.com/university-service/internal/textutil"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryStore é uma implementação de UniversityStore em memória, para
+// desenvolvimento e testes sem MongoDB. Segue as mesmas regras do
+// UniversityRepository; os dados são perdidos quando o processo termina.
+type MemoryStore struct {
+	versionedWrites
+	scanQueries
+
+	mu           sync.RWMutex
+	universities map[primitive.ObjectID]models.University
+	revisions    []models.Revision
+}
+
+func NewMemoryStore() *MemoryStore {
+	s := &MemoryStore{universities: map[primitive.ObjectID]models.University{}}
+	s.versionedWrites = versionedWrites{update: s.update}
+	s.scanQueries = scanQueries{find: s.find}
+	return s
+}
+
+// bsonNow retorna o instante atual com a precisão de milissegundos do BSON, para
+// que os registros lidos tenham os mesmos timestamps que teriam no MongoDB.
+func bsonNow() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
+
+// stored normaliza a cópia gravada no mapa, sem compartilhar ponteiros com o
+// chamador.
+func stored(university models.University) models.University {
+	university.CreatedAt = university.CreatedAt.UTC().Truncate(time.Millisecond)
+	university.UpdatedAt = university.UpdatedAt.UTC().Truncate(time.Millisecond)
+	if university.DeletedAt != nil {
+		deletedAt := university.DeletedAt.UTC().Truncate(time.Millisecond)
+		university.DeletedAt = &deletedAt
+	}
+	return university
+}
+
+// clone devolve uma cópia que o chamador pode alterar livremente.
+func clone(university models.University) models.University {
+	if university.DeletedAt != nil {
+		deletedAt := *university.DeletedAt
+		university.DeletedAt = &deletedAt
+	}
+	return university
+}
+
+func (s *MemoryStore) Create(ctx context.Context, university *models.University) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if university.ID.IsZero() {
+		university.ID = primitive.NewObjectID()
+	} else if _, ok := s.universities[university.ID]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicate, university.ID.Hex())
+	}
+
+	university.CreatedAt = time.Now()
+	university.UpdatedAt = time.Now()
+	university.NameNormalized = textutil.Fold(university.Name)
+	university.Version = 1
+
+	s.universities[university.ID] = stored(*university)
+	return nil
+}
+
+// WithTransaction apenas executa fn: o MemoryStore não tem transações, e o
+// outbox, que depende delas, só está disponível com o MongoDB.
+func (s *MemoryStore) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (s *MemoryStore) GetByID(ctx context.Context, id string) (*models.University, error) {
+	return s.getByID(id, false)
+}
+
+func (s *MemoryStore) GetByIDIncludingDeleted(ctx context.Context, id string) (*models.University, error) {
+	return s.getByID(id, true)
+}
+
+func (s *MemoryStore) getByID(id string, includeDeleted bool) (*models.University, error) {
+	objectID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	university, ok := s.universities[objectID]
+	if !ok || (!includeDeleted && university.DeletedAt != nil) {
+		return nil, ErrNotFound
+	}
+	university = clone(university)
+	return &university, nil
+}
+
+// update aplica apply ao registro se ele estiver na versão esperada e guarda
+// o estado anterior como revisão. Registros excluídos logicamente só são
+// alterados com includeDeleted.
+func (s *MemoryStore) update(ctx context.Context, id primitive.ObjectID, expectedVersion int64, includeDeleted bool, action string, apply func(models.University) (models.University, error)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.universities[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if !includeDeleted && current.DeletedAt != nil {
+		return ErrNotFound
+	}
+	if current.Version != expectedVersion {
+		return ErrVersionConflict
+	}
+
+	updated, err := apply(clone(current))
+	if err != nil {
+		return err
+	}
+	updated.ID = id
+
+	s.universities[id] = stored(updated)
+	s.recordRevision(ctx, current, action)
+	return nil
+}
+
+// recordRevision deve ser chamado com s.mu bloqueado.
+func (s *MemoryStore) recordRevision(ctx context.Context, previous models.University, action string) {
+	s.revisions = append(s.revisions, models.Revision{
+		ID:           primitive.NewObjectID(),
+		UniversityID: previous.ID,
+		Revision:     previous.Version,
+		Action:       action,
+		ChangedBy:    actorFrom(ctx),
+		ChangedAt:    bsonNow(),
+		Snapshot:     previous,
+	})
+}
+
+func (s *MemoryStore) Purge(ctx context.Context, deletedBefore time.Time) ([]models.University, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	purged := make([]models.University, 0)
+	for _, university := range s.sorted(func(u models.University) bool {
+		return u.DeletedAt != nil && u.DeletedAt.Before(deletedBefore)
+	}) {
+		delete(s.universities, university.ID)
+		s.recordRevision(ctx, university, models.RevisionPurged)
+		purged = append(purged, clone(university))
+	}
+	return purged, nil
+}
+
+func (s *MemoryStore) ListRevisions(ctx context.Context, id string) ([]models.Revision, error) {
+	objectID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	revisions := make([]models.Revision, 0)
+	for _, revision := range s.revisions {
+		if revision.UniversityID == objectID {
+			revision.Snapshot = clone(revision.Snapshot)
+			revisions = append(revisions, revision)
+		}
+	}
+	sort.SliceStable(revisions, func(i, j int) bool {
+		return revisions[i].Revision < revisions[j].Revision
+	})
+	return revisions, nil
+}
+
+func (s *MemoryStore) GetRevision(ctx context.Context, id string, revision int64) (*models.Revision, error) {
+	objectID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, r := range s.revisions {
+		if r.UniversityID == objectID && r.Revision == revision {
+			r.Snapshot = clone(r.Snapshot)
+			return &r, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// sorted retorna, em ordem de _id, os registros aceitos por match. Deve ser
+// chamado com s.mu bloqueado.
+func (s *MemoryStore) sorted(match func(models.University) bool) []models.University {
+	universities := make([]models.University, 0)
+	for _, university := range s.universities {
+		if match(university) {
+			universities = append(universities, clone(university))
+		}
+	}
+	sort.Slice(universities, func(i, j int) bool {
+		return compareIDs(universities[i].ID, universities[j].ID) < 0
+	})
+	return universities
+}
+
+func (s *MemoryStore) find(ctx context.Context, opts ListOptions) ([]models.University, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.sorted(func(u models.University) bool {
+		return matchesListOptions(u, opts)
+	}), nil
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/university-service/internal/models"
+)
+
+func TestMemoryStore_Contract(t *testing.T) {
+	testUniversityStore(t, func(t *testing.T) UniversityStore {
+		return NewMemoryStore()
+	})
+}
+
+func TestMemoryStore_Copies(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	uni := &models.University{Name: "Unicamp"}
+	require.NoError(t, store.Create(ctx, uni))
+	uni.Name = "Changed"
+
+	found, err := store.GetByID(ctx, uni.ID.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, "Unicamp", found.Name)
+
+	// Alterar o registro retornado não altera o armazenado
+	require.NoError(t, store.Delete(ctx, found))
+	*found.DeletedAt = time.Time{}
+
+	deleted, err := store.GetByIDIncludingDeleted(ctx, uni.ID.Hex())
+	require.NoError(t, err)
+	assert.False(t, deleted.DeletedAt.IsZero())
+}
@@ -0,0 +1,38 @@
+-- Universidades e revisões, com os mesmos campos das coleções do MongoDB. Os IDs
+-- são UUIDv7 obtidos dos ObjectIDs usados pela API e pelos eventos (veja
+-- postgres_ids.go), e mantêm a mesma ordem.
+CREATE TABLE universities (
+    id                 UUID PRIMARY KEY,
+    name               TEXT NOT NULL,
+    name_normalized    TEXT COLLATE "C" NOT NULL,
+    address            TEXT NOT NULL,
+    address_normalized TEXT NOT NULL,
+    phone              TEXT NOT NULL,
+    email              TEXT NOT NULL,
+    website            TEXT NOT NULL,
+    created_at         TIMESTAMPTZ NOT NULL,
+    updated_at         TIMESTAMPTZ NOT NULL,
+    version            BIGINT NOT NULL,
+    deleted_at         TIMESTAMPTZ,
+    -- Mesmos pesos do índice de texto do MongoDB: name 10, address 5
+    search             TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('portuguese', name_normalized), 'A') ||
+        setweight(to_tsvector('portuguese', address_normalized), 'B')
+    ) STORED
+);
+
+CREATE INDEX universities_search ON universities USING GIN (search);
+CREATE INDEX universities_name_normalized ON universities (name_normalized);
+CREATE INDEX universities_created_at ON universities (created_at, id);
+CREATE INDEX universities_deleted_at ON universities (deleted_at) WHERE deleted_at IS NOT NULL;
+
+CREATE TABLE university_revisions (
+    id            UUID PRIMARY KEY,
+    university_id UUID NOT NULL,
+    revision      BIGINT NOT NULL,
+    action        TEXT NOT NULL,
+    changed_by    TEXT NOT NULL DEFAULT '',
+    changed_at    TIMESTAMPTZ NOT NULL,
+    snapshot      JSONB NOT NULL,
+    UNIQUE (university_id, revision)
+);
@@ -2,84 +2,293 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/university-service/internal/models"
+	"github.com/university-service/internal/textutil"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type UniversityRepository struct {
 	collection *mongo.Collection
+	revisions  *mongo.Collection
 }
 
 func NewUniversityRepository(db *mongo.Database) *UniversityRepository {
 	return &UniversityRepository{
 		collection: db.Collection("universities"),
+		revisions:  db.Collection("university_revisions"),
 	}
 }
 
+// mongoError traduz os erros do driver para os de errors.go. Erros que já são
+// do pacote, ou que não têm equivalente, são retornados sem alteração.
+func mongoError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return ErrNotFound
+	case mongo.IsDuplicateKeyError(err):
+		return fmt.Errorf("%w: %w", ErrDuplicate, err)
+	case mongo.IsTimeout(err), mongo.IsNetworkError(err), errors.Is(err, mongo.ErrClientDisconnected):
+		return fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+	return err
+}
+
 func (r *UniversityRepository) Create(ctx context.Context, university *models.University) error {
 	university.CreatedAt = time.Now()
 	university.UpdatedAt = time.Now()
+	university.NameNormalized = textutil.Fold(university.Name)
+	university.Version = 1
 	
 	result, err := r.collection.InsertOne(ctx, university)
 	if err != nil {
-		return err
+		return mongoError(err)
 	}
 	
 	university.ID = result.InsertedID.(primitive.ObjectID)
 	return nil
 }
 
+// WithTransaction executa fn em uma transação do MongoDB. As operações dos
+// repositórios chamadas com o contexto recebido por fn fazem parte dela e são
+// confirmadas ou desfeitas em conjunto. Requer um replica set.
+func (r *UniversityRepository) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withTransaction(ctx, r.collection.Database().Client(), fn)
+}
+
+func withTransaction(ctx context.Context, client *mongo.Client, fn func(ctx context.Context) error) error {
+	session, err := client.StartSession()
+	if err != nil {
+		return mongoError(err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessionCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessionCtx)
+	})
+	return mongoError(err)
+}
+
+// notDeleted seleciona apenas documentos que não foram excluídos logicamente.
+var notDeleted = bson.M{"deleted_at": nil}
+
+// GetByID busca uma universidade ignorando as excluídas logicamente.
 func (r *UniversityRepository) GetByID(ctx context.Context, id string) (*models.University, error) {
-	objectID, err := primitive.ObjectIDFromHex(id)
+	return r.getByID(ctx, id, false)
+}
+
+// GetByIDIncludingDeleted busca uma universidade mesmo que ela tenha sido
+// excluída logicamente.
+func (r *UniversityRepository) GetByIDIncludingDeleted(ctx context.Context, id string) (*models.University, error) {
+	return r.getByID(ctx, id, true)
+}
+
+func (r *UniversityRepository) getByID(ctx context.Context, id string, includeDeleted bool) (*models.University, error) {
+	objectID, err := parseID(id)
 	if err != nil {
 		return nil, err
 	}
 
+	filter := bson.M{"_id": objectID}
+	if !includeDeleted {
+		filter["deleted_at"] = nil
+	}
+
 	var university models.University
-	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&university)
+	err = r.collection.FindOne(ctx, filter).Decode(&university)
 	if err != nil {
-		return nil, err
+		return nil, mongoError(err)
 	}
 
 	return &university, nil
 }
 
-func (r *UniversityRepository) GetAll(ctx context.Context) ([]models.University, error) {
-	cursor, err := r.collection.Find(ctx, bson.M{})
+// Update substitui o documento desde que ele ainda esteja na versão informada
+// em university.Version, que é incrementada em caso de sucesso.
+func (r *UniversityRepository) Update(ctx context.Context, university *models.University) error {
+	expectedVersion := university.Version
+	university.UpdatedAt = time.Now()
+	university.NameNormalized = textutil.Fold(university.Name)
+	university.Version = expectedVersion + 1
+
+	filter := versionFilter(university.ID, expectedVersion)
+	filter["deleted_at"] = nil
+
+	err := r.updateAndRecord(ctx, university.ID, filter, bson.M{"$set": university}, models.RevisionUpdated)
 	if err != nil {
-		return nil, err
+		university.Version = expectedVersion
+		return err
 	}
-	defer cursor.Close(ctx)
+	return nil
+}
 
-	var universities []models.University
-	if err = cursor.All(ctx, &universities); err != nil {
-		return nil, err
+// versionFilter seleciona o documento apenas se ele estiver na versão
+// esperada. Documentos gravados antes do controle de versão não têm o campo e
+// são tratados como versão 0.
+func versionFilter(id primitive.ObjectID, version int64) bson.M {
+	if version == 0 {
+		return bson.M{"_id": id, "version": bson.M{"$in": bson.A{0, nil}}}
+	}
+	return bson.M{"_id": id, "version": version}
+}
+
+// updateAndRecord aplica uma atualização condicional e guarda o estado
+// anterior do documento como uma revisão.
+func (r *UniversityRepository) updateAndRecord(ctx context.Context, id primitive.ObjectID, filter, update bson.M, action string) error {
+	var previous models.University
+	err := r.collection.FindOneAndUpdate(ctx, filter, update,
+		options.FindOneAndUpdate().SetReturnDocument(options.Before),
+	).Decode(&previous)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return r.missOrConflict(ctx, id, filter)
+	}
+	if err != nil {
+		return mongoError(err)
 	}
 
-	return universities, nil
+	return r.recordRevision(ctx, &previous, action)
 }
 
-func (r *UniversityRepository) Update(ctx context.Context, university *models.University) error {
+// missOrConflict distingue, após uma atualização condicional sem efeito, se o
+// documento não existe ou se apenas a versão não confere. Se o filtro da
+// atualização ignorava os excluídos logicamente, eles também contam como
+// inexistentes.
+func (r *UniversityRepository) missOrConflict(ctx context.Context, id primitive.ObjectID, filter bson.M) error {
+	countFilter := bson.M{"_id": id}
+	if deletedAt, ok := filter["deleted_at"]; ok {
+		countFilter["deleted_at"] = deletedAt
+	}
+	count, err := r.collection.CountDocuments(ctx, countFilter)
+	if err != nil {
+		return mongoError(err)
+	}
+	if count == 0 {
+		return ErrNotFound
+	}
+	return ErrVersionConflict
+}
+
+// UpdateFields grava com $set apenas os campos informados, identificados pelo
+// nome da tag bson (que coincide com a tag json nos campos da universidade).
+// Assim como Update, exige que o documento esteja em university.Version.
+func (r *UniversityRepository) UpdateFields(ctx context.Context, university *models.University, fields []string) error {
+	expectedVersion := university.Version
 	university.UpdatedAt = time.Now()
+	university.NameNormalized = textutil.Fold(university.Name)
+	university.Version = expectedVersion + 1
 
-	_, err := r.collection.UpdateOne(
-		ctx,
-		bson.M{"_id": university.ID},
-		bson.M{"$set": university},
-	)
-	return err
+	data, err := bson.Marshal(university)
+	if err != nil {
+		return err
+	}
+	var document bson.M
+	if err := bson.Unmarshal(data, &document); err != nil {
+		return err
+	}
+
+	set := bson.M{"updated_at": university.UpdatedAt, "version": university.Version}
+	for _, field := range fields {
+		value, ok := document[field]
+		if !ok {
+			university.Version = expectedVersion
+			return fmt.Errorf("unknown field: %s", field)
+		}
+		set[field] = value
+		if field == "name" {
+			set["name_normalized"] = university.NameNormalized
+		}
+	}
+
+	filter := versionFilter(university.ID, expectedVersion)
+	filter["deleted_at"] = nil
+
+	err = r.updateAndRecord(ctx, university.ID, filter, bson.M{"$set": set}, models.RevisionPatched)
+	if err != nil {
+		university.Version = expectedVersion
+		return err
+	}
+	return nil
+}
+
+// Delete exclui a universidade logicamente, preenchendo deleted_at. O
+// documento continua no banco até ser removido por Purge e pode ser
+// recuperado com Restore. Assim como Update, exige a versão informada.
+func (r *UniversityRepository) Delete(ctx context.Context, university *models.University) error {
+	now := time.Now()
+	update := bson.M{"$set": bson.M{"deleted_at": now}}
+	if err := r.updateVersion(ctx, university, now, update, models.RevisionDeleted); err != nil {
+		return err
+	}
+	university.DeletedAt = &now
+	return nil
 }
 
-func (r *UniversityRepository) Delete(ctx context.Context, id string) error {
-	objectID, err := primitive.ObjectIDFromHex(id)
+// Restore desfaz a exclusão lógica de uma universidade.
+func (r *UniversityRepository) Restore(ctx context.Context, university *models.University) error {
+	update := bson.M{"$set": bson.M{}, "$unset": bson.M{"deleted_at": ""}}
+	if err := r.updateVersion(ctx, university, time.Now(), update, models.RevisionRestored); err != nil {
+		return err
+	}
+	university.DeletedAt = nil
+	return nil
+}
+
+// updateVersion aplica a atualização se o documento estiver na versão de
+// university.Version, incrementando a versão e atualizando updated_at.
+func (r *UniversityRepository) updateVersion(ctx context.Context, university *models.University, now time.Time, update bson.M, action string) error {
+	expectedVersion := university.Version
+	set := update["$set"].(bson.M)
+	set["updated_at"] = now
+	set["version"] = expectedVersion + 1
+
+	err := r.updateAndRecord(ctx, university.ID, versionFilter(university.ID, expectedVersion), update, action)
 	if err != nil {
 		return err
 	}
 
-	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
-	return err
-}
\ No newline at end of file
+	university.UpdatedAt = now
+	university.Version = expectedVersion + 1
+	return nil
+}
+
+// Purge remove definitivamente as universidades excluídas logicamente antes
+// do instante informado e retorna os documentos removidos.
+func (r *UniversityRepository) Purge(ctx context.Context, deletedBefore time.Time) ([]models.University, error) {
+	filter := bson.M{"deleted_at": bson.M{"$lt": deletedBefore}}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, mongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	var candidates []models.University
+	if err = cursor.All(ctx, &candidates); err != nil {
+		return nil, mongoError(err)
+	}
+
+	purged := make([]models.University, 0, len(candidates))
+	for _, university := range candidates {
+		// Repete o filtro para não remover um registro restaurado nesse meio tempo
+		result, err := r.collection.DeleteOne(ctx, bson.M{"_id": university.ID, "deleted_at": bson.M{"$lt": deletedBefore}})
+		if err != nil {
+			return purged, mongoError(err)
+		}
+		if result.DeletedCount == 1 {
+			purged = append(purged, university)
+			if err := r.recordRevision(ctx, &university, models.RevisionPurged); err != nil {
+				return purged, mongoError(err)
+			}
+		}
+	}
+
+	return purged, nil
+}
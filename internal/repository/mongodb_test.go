@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"os"
 	"testing"
 	"time"
 
@@ -13,16 +14,30 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// setupTestDB conecta ao MongoDB informado em MONGODB_TEST_URI e cria um banco
+// exclusivo para o teste, removido na limpeza. Sem a variável o teste é
+// ignorado.
 func setupTestDB(t *testing.T) (*mongo.Database, func()) {
-	ctx := context.Background()
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI("mongodb://localhost:27017"))
+	uri := os.Getenv("MONGODB_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGODB_TEST_URI not set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
 	if err != nil {
 		t.Fatalf("Failed to connect to MongoDB: %v", err)
 	}
+	if err := client.Ping(ctx, nil); err != nil {
+		t.Fatalf("Failed to ping MongoDB: %v", err)
+	}
 
-	db := client.Database("test_db")
+	db := client.Database("test_db_" + primitive.NewObjectID().Hex())
 
 	return db, func() {
+		ctx := context.Background()
 		if err := db.Drop(ctx); err != nil {
 			t.Errorf("Failed to drop test database: %v", err)
 		}
@@ -32,6 +47,19 @@ func setupTestDB(t *testing.T) (*mongo.Database, func()) {
 	}
 }
 
+func TestUniversityRepository_Contract(t *testing.T) {
+	testUniversityStore(t, func(t *testing.T) UniversityStore {
+		db, cleanup := setupTestDB(t)
+		t.Cleanup(cleanup)
+
+		repo := NewUniversityRepository(db)
+		if err := repo.EnsureIndexes(context.Background()); err != nil {
+			t.Fatalf("Failed to create indexes: %v", err)
+		}
+		return repo
+	})
+}
+
 func TestUniversityRepository_CRUD(t *testing.T) {
 	db, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -76,8 +104,8 @@ func TestUniversityRepository_CRUD(t *testing.T) {
 		assert.Equal(t, uni.Name, found.Name)
 	})
 
-	// Test GetAll
-	t.Run("GetAll", func(t *testing.T) {
+	// Test List
+	t.Run("List", func(t *testing.T) {
 		// Clear collection
 		_, err := repo.collection.DeleteMany(ctx, bson.M{})
 		assert.NoError(t, err)
@@ -103,9 +131,10 @@ func TestUniversityRepository_CRUD(t *testing.T) {
 		_, err = repo.collection.InsertMany(ctx, unis)
 		assert.NoError(t, err)
 
-		results, err := repo.GetAll(ctx)
+		results, total, err := repo.List(ctx, ListOptions{})
 		assert.NoError(t, err)
 		assert.Len(t, results, 2)
+		assert.Equal(t, int64(2), total)
 	})
 
 	// Test Update
@@ -133,6 +162,47 @@ func TestUniversityRepository_CRUD(t *testing.T) {
 		assert.Equal(t, "New Name", updated.Name)
 	})
 
+	// Test Revisions
+	t.Run("Revisions", func(t *testing.T) {
+		uni := &models.University{
+			Name:    "Revised University",
+			Address: "Revision Address",
+			Phone:   "(11) 1234-5678",
+			Email:   "before@test.edu",
+		}
+		assert.NoError(t, repo.Create(ctx, uni))
+
+		uni.Email = "after@test.edu"
+		assert.NoError(t, repo.Update(WithActor(ctx, "auditor"), uni))
+
+		revisions, err := repo.ListRevisions(ctx, uni.ID.Hex())
+		assert.NoError(t, err)
+		assert.Len(t, revisions, 1)
+		assert.Equal(t, int64(1), revisions[0].Revision)
+		assert.Equal(t, models.RevisionUpdated, revisions[0].Action)
+		assert.Equal(t, "auditor", revisions[0].ChangedBy)
+		assert.Equal(t, "before@test.edu", revisions[0].Snapshot.Email)
+
+		revision, err := repo.GetRevision(ctx, uni.ID.Hex(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, uni.ID, revision.UniversityID)
+
+		// Revert
+		reverted := revision.Snapshot
+		reverted.Version = uni.Version
+		assert.NoError(t, repo.Revert(ctx, &reverted))
+
+		current, err := repo.GetByID(ctx, uni.ID.Hex())
+		assert.NoError(t, err)
+		assert.Equal(t, "before@test.edu", current.Email)
+		assert.Equal(t, int64(3), current.Version)
+
+		revisions, err = repo.ListRevisions(ctx, uni.ID.Hex())
+		assert.NoError(t, err)
+		assert.Len(t, revisions, 2)
+		assert.Equal(t, models.RevisionReverted, revisions[1].Action)
+	})
+
 	// Test Delete
 	t.Run("Delete", func(t *testing.T) {
 		uni := &models.University{
@@ -146,10 +216,109 @@ func TestUniversityRepository_CRUD(t *testing.T) {
 		_, err := repo.collection.InsertOne(ctx, uni)
 		assert.NoError(t, err)
 
-		err = repo.Delete(ctx, uni.ID.Hex())
+		err = repo.Delete(ctx, uni)
 		assert.NoError(t, err)
 
 		_, err = repo.GetByID(ctx, uni.ID.Hex())
 		assert.Error(t, err)
+
+		deleted, err := repo.GetByIDIncludingDeleted(ctx, uni.ID.Hex())
+		assert.NoError(t, err)
+		assert.NotNil(t, deleted.DeletedAt)
 	})
-}
\ No newline at end of file
+
+	// Test Restore
+	t.Run("Restore", func(t *testing.T) {
+		uni := &models.University{
+			Name:    "To Restore",
+			Address: "Restore Address",
+			Phone:   "(11) 1234-5678",
+			Email:   "restore@test.edu",
+		}
+		assert.NoError(t, repo.Create(ctx, uni))
+		assert.NoError(t, repo.Delete(ctx, uni))
+
+		err := repo.Restore(ctx, uni)
+		assert.NoError(t, err)
+		assert.Nil(t, uni.DeletedAt)
+
+		restored, err := repo.GetByID(ctx, uni.ID.Hex())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), restored.Version)
+	})
+
+	// Test Each
+	t.Run("Each", func(t *testing.T) {
+		var names []string
+		err := repo.Each(ctx, ListOptions{Name: "test"}, func(u *models.University) error {
+			names = append(names, u.Name)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, names)
+
+		count := 0
+		err = repo.Each(ctx, ListOptions{IncludeDeleted: true, Limit: 1}, func(u *models.University) error {
+			count++
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	// Test Purge
+	t.Run("Purge", func(t *testing.T) {
+		uni := &models.University{
+			Name:    "To Purge",
+			Address: "Purge Address",
+			Phone:   "(11) 1234-5678",
+			Email:   "purge@test.edu",
+		}
+		assert.NoError(t, repo.Create(ctx, uni))
+		assert.NoError(t, repo.Delete(ctx, uni))
+
+		purged, err := repo.Purge(ctx, time.Now().Add(time.Minute))
+		assert.NoError(t, err)
+		assert.NotEmpty(t, purged)
+
+		_, err = repo.GetByIDIncludingDeleted(ctx, uni.ID.Hex())
+		assert.Error(t, err)
+	})
+}
+
+func TestMongoError(t *testing.T) {
+	assert.NoError(t, mongoError(nil))
+	assert.Equal(t, ErrNotFound, mongoError(mongo.ErrNoDocuments))
+
+	duplicate := mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000, Message: "E11000 duplicate key"}}}
+	assert.ErrorIs(t, mongoError(duplicate), ErrDuplicate)
+
+	assert.ErrorIs(t, mongoError(context.DeadlineExceeded), ErrUnavailable)
+	assert.ErrorIs(t, mongoError(mongo.CommandError{Labels: []string{"NetworkError"}}), ErrUnavailable)
+	assert.ErrorIs(t, mongoError(mongo.ErrClientDisconnected), ErrUnavailable)
+
+	// Erros do próprio pacote não são alterados
+	assert.Equal(t, ErrVersionConflict, mongoError(ErrVersionConflict))
+}
+
+func TestUniversityRepository_BackfillNormalizedNames(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := NewUniversityRepository(db)
+
+	// Um lote completo e mais um documento, gravados sem name_normalized
+	docs := make([]interface{}, 0, backfillBatchSize+1)
+	for i := 0; i <= backfillBatchSize; i++ {
+		docs = append(docs, bson.M{"_id": primitive.NewObjectID(), "name": "Universidade São João"})
+	}
+	_, err := db.Collection("universities").InsertMany(ctx, docs)
+	assert.NoError(t, err)
+
+	assert.NoError(t, repo.BackfillNormalizedNames(ctx))
+
+	pending, err := db.Collection("universities").CountDocuments(ctx, bson.M{"name_normalized": bson.M{"$ne": "universidade sao joao"}})
+	assert.NoError(t, err)
+	assert.Zero(t, pending)
+}
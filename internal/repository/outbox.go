@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/university-service/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// OutboxRepository guarda os eventos pendentes de publicação na coleção
+// outbox. Enqueue deve ser chamado dentro da mesma transação da alteração
+// (veja UniversityRepository.WithTransaction).
+type OutboxRepository struct {
+	collection *mongo.Collection
+}
+
+func NewOutboxRepository(db *mongo.Database) *OutboxRepository {
+	return &OutboxRepository{
+		collection: db.Collection("outbox"),
+	}
+}
+
+// EnsureIndexes cria o índice usado pelo relay e, se retention for maior que
+// zero, um índice TTL que remove os registros entregues após esse período.
+func (r *OutboxRepository) EnsureIndexes(ctx context.Context, retention time.Duration) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("outbox_status"),
+		},
+	}
+	if retention > 0 {
+		indexes = append(indexes, mongo.IndexModel{
+			Keys:    bson.D{{Key: "delivered_at", Value: 1}},
+			Options: options.Index().SetName("outbox_delivered_ttl").SetExpireAfterSeconds(int32(retention.Seconds())),
+		})
+	}
+
+	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
+	return mongoError(err)
+}
+
+// CheckTransactions verifica se o servidor aceita transações, exigidas pelo
+// outbox: o MongoDB precisa estar em replica set (ou ser um mongos).
+func (r *OutboxRepository) CheckTransactions(ctx context.Context) error {
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	err := r.collection.Database().RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello)
+	if err != nil {
+		return mongoError(err)
+	}
+	if hello.SetName == "" && hello.Msg != "isdbgrid" {
+		return errors.New("MongoDB is not running as a replica set and does not support transactions")
+	}
+	return nil
+}
+
+func (r *OutboxRepository) Enqueue(ctx context.Context, events ...*models.UniversityEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	records := make([]interface{}, 0, len(events))
+	for _, event := range events {
+		records = append(records, models.OutboxRecord{
+			Event:         *event,
+			Status:        models.OutboxPending,
+			CreatedAt:     now,
+			NextAttemptAt: now,
+		})
+	}
+
+	_, err := r.collection.InsertMany(ctx, records)
+	return mongoError(err)
+}
+
+// FetchPending retorna, na ordem em que foram gravados, até limit registros
+// prontos para publicação. Apenas o registro mais antigo ainda não entregue de
+// cada universidade é considerado, e só se estiver pendente, fora da espera de
+// uma nova tentativa e sem reserva: assim os eventos de uma universidade saem
+// na ordem em que foram gravados, e universidades bloqueadas não ocupam o lote.
+// Um registro com status failed bloqueia os eventos seguintes da mesma
+// universidade até ser removido ou voltar a pending.
+func (r *OutboxRepository) FetchPending(ctx context.Context, limit int64) ([]models.OutboxRecord, error) {
+	now := time.Now()
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"status": bson.M{"$in": bson.A{models.OutboxPending, models.OutboxFailed}}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$event.university._id", "head": bson.M{"$first": "$$ROOT"}}}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$head"}}},
+		{{Key: "$match", Value: bson.M{
+			"status":          models.OutboxPending,
+			"next_attempt_at": bson.M{"$lte": now},
+			"$or": bson.A{
+				bson.M{"claimed_until": nil},
+				bson.M{"claimed_until": bson.M{"$lt": now}},
+			},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, mongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	records := make([]models.OutboxRecord, 0)
+	if err = cursor.All(ctx, &records); err != nil {
+		return nil, mongoError(err)
+	}
+
+	return records, nil
+}
+
+// Claim reserva o registro até o instante informado, evitando que outra
+// instância do relay o publique ao mesmo tempo. Retorna false se o registro
+// já estiver reservado ou não estiver mais pendente.
+func (r *OutboxRepository) Claim(ctx context.Context, id primitive.ObjectID, until time.Time) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{
+			"_id":    id,
+			"status": models.OutboxPending,
+			"$or": bson.A{
+				bson.M{"claimed_until": nil},
+				bson.M{"claimed_until": bson.M{"$lt": time.Now()}},
+			},
+		},
+		bson.M{"$set": bson.M{"claimed_until": until}},
+	)
+	if err != nil {
+		return false, mongoError(err)
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+func (r *OutboxRepository) MarkDelivered(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.UpdateByID(ctx, id, bson.M{
+		"$set":   bson.M{"status": models.OutboxDelivered, "delivered_at": time.Now()},
+		"$inc":   bson.M{"attempts": 1},
+		"$unset": bson.M{"claimed_until": "", "last_error": ""},
+	})
+	return mongoError(err)
+}
+
+// MarkFailed registra uma tentativa malsucedida. Com final, o registro deixa
+// de ser tentado; caso contrário, volta a ficar disponível em nextAttempt.
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id primitive.ObjectID, cause error, nextAttempt time.Time, final bool) error {
+	status := models.OutboxPending
+	if final {
+		status = models.OutboxFailed
+	}
+
+	_, err := r.collection.UpdateByID(ctx, id, bson.M{
+		"$set":   bson.M{"status": status, "last_error": cause.Error(), "next_attempt_at": nextAttempt},
+		"$inc":   bson.M{"attempts": 1},
+		"$unset": bson.M{"claimed_until": ""},
+	})
+	return mongoError(err)
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/university-service/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestOutboxRepository_FetchPending(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	outbox := NewOutboxRepository(db)
+	require.NoError(t, outbox.EnsureIndexes(ctx, 0))
+
+	first := &models.University{ID: primitive.NewObjectID(), Name: "Primeira"}
+	second := &models.University{ID: primitive.NewObjectID(), Name: "Segunda"}
+	require.NoError(t, outbox.Enqueue(ctx,
+		models.NewUniversityEvent(models.EventUniversityCreated, first),
+		models.NewUniversityEvent(models.EventUniversityUpdated, first),
+		models.NewUniversityEvent(models.EventUniversityCreated, second),
+	))
+
+	describe := func(records []models.OutboxRecord) []string {
+		ids := make([]string, 0, len(records))
+		for _, record := range records {
+			ids = append(ids, record.Event.Type+":"+record.Event.University.Name)
+		}
+		return ids
+	}
+
+	// Apenas o primeiro evento de cada universidade
+	records, err := outbox.FetchPending(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"university_created:Primeira", "university_created:Segunda"}, describe(records))
+	head := records[0]
+
+	// Em espera, a primeira universidade não ocupa o lote
+	require.NoError(t, outbox.MarkFailed(ctx, head.ID, errors.New("broker down"), time.Now().Add(time.Hour), false))
+	records, err = outbox.FetchPending(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"university_created:Segunda"}, describe(records))
+
+	// Após a falha definitiva, os eventos seguintes continuam retidos
+	require.NoError(t, outbox.MarkFailed(ctx, head.ID, errors.New("broker down"), time.Now(), true))
+	records, err = outbox.FetchPending(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"university_created:Segunda"}, describe(records))
+
+	// Entregue o evento da segunda universidade, não há mais nada pronto
+	require.NoError(t, outbox.MarkDelivered(ctx, records[0].ID))
+	records, err = outbox.FetchPending(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
@@ -0,0 +1,572 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/university-service/internal/models"
+	"github.com/university-service/internal/textutil"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PostgresStore é uma implementação de UniversityStore no PostgreSQL. As
+// tabelas são criadas por Migrate, com IDs uuid (veja uuidFromObjectID); a
+// busca textual usa uma coluna tsvector com os mesmos pesos do índice de texto
+// do MongoDB.
+type PostgresStore struct {
+	versionedWrites
+
+	pool *pgxpool.Pool
+}
+
+func NewPostgresStore(pool *pgxpool.Pool) *PostgresStore {
+	s := &PostgresStore{pool: pool}
+	s.versionedWrites = versionedWrites{update: s.update}
+	return s
+}
+
+// querier é implementado por *pgxpool.Pool e por pgx.Tx.
+type querier interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// postgresError traduz os erros do pgx para os de errors.go. Erros que já são
+// do pacote, ou que não têm equivalente, são retornados sem alteração.
+func postgresError(err error) error {
+	var pgErr *pgconn.PgError
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, pgx.ErrNoRows):
+		return ErrNotFound
+	// 23505 é unique_violation; a classe 08 reúne as falhas de conexão
+	case errors.As(err, &pgErr) && pgErr.Code == "23505":
+		return fmt.Errorf("%w: %w", ErrDuplicate, err)
+	case errors.As(err, &pgErr) && strings.HasPrefix(pgErr.Code, "08"),
+		errors.As(err, &connectErr), errors.As(err, &netErr), pgconn.Timeout(err):
+		return fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+	return err
+}
+
+type postgresTxKey struct{}
+
+// db retorna a transação aberta por WithTransaction, se houver.
+func (s *PostgresStore) db(ctx context.Context) querier {
+	if tx, ok := ctx.Value(postgresTxKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return s.pool
+}
+
+// WithTransaction executa fn em uma transação. Dentro de outra transação, fn
+// roda em um savepoint.
+func (s *PostgresStore) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := s.db(ctx).Begin(ctx)
+	if err != nil {
+		return postgresError(err)
+	}
+	defer tx.Rollback(context.Background())
+
+	if err := fn(context.WithValue(ctx, postgresTxKey{}, tx)); err != nil {
+		return err
+	}
+	return postgresError(tx.Commit(ctx))
+}
+
+const universityColumns = "id, name, address, phone, email, website, created_at, updated_at, version, deleted_at, name_normalized"
+
+// scanUniversity lê as colunas de universityColumns, seguidas de extra.
+func scanUniversity(row pgx.Row, extra ...any) (models.University, error) {
+	var university models.University
+	var id pgtype.UUID
+	dest := append([]any{
+		&id, &university.Name, &university.Address, &university.Phone, &university.Email, &university.Website,
+		&university.CreatedAt, &university.UpdatedAt, &university.Version, &university.DeletedAt, &university.NameNormalized,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return university, postgresError(err)
+	}
+
+	objectID, err := objectIDFromUUID(id)
+	if err != nil {
+		return university, err
+	}
+	university.ID = objectID
+
+	// Mesmo fuso devolvido pelo driver do MongoDB
+	university.CreatedAt = university.CreatedAt.UTC()
+	university.UpdatedAt = university.UpdatedAt.UTC()
+	if university.DeletedAt != nil {
+		deletedAt := university.DeletedAt.UTC()
+		university.DeletedAt = &deletedAt
+	}
+	return university, nil
+}
+
+func scanUniversities(rows pgx.Rows) ([]models.University, error) {
+	defer rows.Close()
+
+	universities := make([]models.University, 0)
+	for rows.Next() {
+		university, err := scanUniversity(rows)
+		if err != nil {
+			return nil, err
+		}
+		universities = append(universities, university)
+	}
+	return universities, postgresError(rows.Err())
+}
+
+func (s *PostgresStore) Create(ctx context.Context, university *models.University) error {
+	id := university.ID
+	if id.IsZero() {
+		id = primitive.NewObjectID()
+	}
+
+	university.CreatedAt = time.Now()
+	university.UpdatedAt = time.Now()
+	university.NameNormalized = textutil.Fold(university.Name)
+	university.Version = 1
+
+	_, err := s.db(ctx).Exec(ctx, `
+		INSERT INTO universities (id, name, name_normalized, address, address_normalized, phone, email, website,
+			created_at, updated_at, version, deleted_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+		uuidFromObjectID(id), university.Name, university.NameNormalized, university.Address, textutil.Fold(university.Address),
+		university.Phone, university.Email, university.Website,
+		university.CreatedAt, university.UpdatedAt, university.Version, university.DeletedAt,
+	)
+	if err != nil {
+		return postgresError(err)
+	}
+
+	university.ID = id
+	return nil
+}
+
+func (s *PostgresStore) GetByID(ctx context.Context, id string) (*models.University, error) {
+	return s.getByID(ctx, id, false)
+}
+
+func (s *PostgresStore) GetByIDIncludingDeleted(ctx context.Context, id string) (*models.University, error) {
+	return s.getByID(ctx, id, true)
+}
+
+func (s *PostgresStore) getByID(ctx context.Context, id string, includeDeleted bool) (*models.University, error) {
+	objectID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT " + universityColumns + " FROM universities WHERE id = $1"
+	if !includeDeleted {
+		query += " AND deleted_at IS NULL"
+	}
+
+	university, err := scanUniversity(s.db(ctx).QueryRow(ctx, query, uuidFromObjectID(objectID)))
+	if err != nil {
+		return nil, err
+	}
+	return &university, nil
+}
+
+// update bloqueia a linha com SELECT ... FOR UPDATE, de forma que
+// atualizações concorrentes da mesma universidade sejam serializadas e apenas
+// a primeira encontre a versão esperada.
+func (s *PostgresStore) update(ctx context.Context, id primitive.ObjectID, expectedVersion int64, includeDeleted bool, action string, apply func(models.University) (models.University, error)) error {
+	return s.WithTransaction(ctx, func(ctx context.Context) error {
+		db := s.db(ctx)
+
+		current, err := scanUniversity(db.QueryRow(ctx,
+			"SELECT "+universityColumns+" FROM universities WHERE id = $1 FOR UPDATE", uuidFromObjectID(id)))
+		if err != nil {
+			return err
+		}
+		if !includeDeleted && current.DeletedAt != nil {
+			return ErrNotFound
+		}
+		if current.Version != expectedVersion {
+			return ErrVersionConflict
+		}
+
+		updated, err := apply(current)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(ctx, `
+			UPDATE universities SET name = $2, name_normalized = $3, address = $4, address_normalized = $5,
+				phone = $6, email = $7, website = $8, created_at = $9, updated_at = $10, version = $11, deleted_at = $12
+			WHERE id = $1`,
+			uuidFromObjectID(id), updated.Name, textutil.Fold(updated.Name), updated.Address, textutil.Fold(updated.Address),
+			updated.Phone, updated.Email, updated.Website,
+			updated.CreatedAt, updated.UpdatedAt, updated.Version, updated.DeletedAt,
+		)
+		if err != nil {
+			return postgresError(err)
+		}
+
+		return s.recordRevision(ctx, &current, action)
+	})
+}
+
+func (s *PostgresStore) recordRevision(ctx context.Context, previous *models.University, action string) error {
+	snapshot, err := json.Marshal(previous)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db(ctx).Exec(ctx, `
+		INSERT INTO university_revisions (id, university_id, revision, action, changed_by, changed_at, snapshot)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		uuidFromObjectID(primitive.NewObjectID()), uuidFromObjectID(previous.ID), previous.Version, action, actorFrom(ctx), time.Now(), snapshot,
+	)
+	return postgresError(err)
+}
+
+func (s *PostgresStore) Purge(ctx context.Context, deletedBefore time.Time) ([]models.University, error) {
+	var purged []models.University
+	err := s.WithTransaction(ctx, func(ctx context.Context) error {
+		rows, err := s.db(ctx).Query(ctx,
+			"DELETE FROM universities WHERE deleted_at < $1 RETURNING "+universityColumns, deletedBefore)
+		if err != nil {
+			return postgresError(err)
+		}
+		if purged, err = scanUniversities(rows); err != nil {
+			return err
+		}
+
+		sort.Slice(purged, func(i, j int) bool {
+			return compareIDs(purged[i].ID, purged[j].ID) < 0
+		})
+		for i := range purged {
+			if err := s.recordRevision(ctx, &purged[i], models.RevisionPurged); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return purged, nil
+}
+
+const revisionColumns = "id, university_id, revision, action, changed_by, changed_at, snapshot"
+
+func scanRevision(row pgx.Row) (models.Revision, error) {
+	var revision models.Revision
+	var id, universityID pgtype.UUID
+	var snapshot []byte
+	err := row.Scan(&id, &universityID, &revision.Revision, &revision.Action, &revision.ChangedBy, &revision.ChangedAt, &snapshot)
+	if err != nil {
+		return revision, postgresError(err)
+	}
+
+	if revision.ID, err = objectIDFromUUID(id); err != nil {
+		return revision, err
+	}
+	if revision.UniversityID, err = objectIDFromUUID(universityID); err != nil {
+		return revision, err
+	}
+	if err := json.Unmarshal(snapshot, &revision.Snapshot); err != nil {
+		return revision, err
+	}
+	// name_normalized não faz parte do JSON da universidade
+	revision.Snapshot.NameNormalized = textutil.Fold(revision.Snapshot.Name)
+	revision.ChangedAt = revision.ChangedAt.UTC()
+	return revision, nil
+}
+
+func (s *PostgresStore) ListRevisions(ctx context.Context, id string) ([]models.Revision, error) {
+	objectID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db(ctx).Query(ctx,
+		"SELECT "+revisionColumns+" FROM university_revisions WHERE university_id = $1 ORDER BY revision, id",
+		uuidFromObjectID(objectID))
+	if err != nil {
+		return nil, postgresError(err)
+	}
+	defer rows.Close()
+
+	revisions := make([]models.Revision, 0)
+	for rows.Next() {
+		revision, err := scanRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, postgresError(rows.Err())
+}
+
+func (s *PostgresStore) GetRevision(ctx context.Context, id string, revision int64) (*models.Revision, error) {
+	objectID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := scanRevision(s.db(ctx).QueryRow(ctx,
+		"SELECT "+revisionColumns+" FROM university_revisions WHERE university_id = $1 AND revision = $2",
+		uuidFromObjectID(objectID), revision))
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// postgresFilter monta a cláusula WHERE equivalente a buildListFilter. Os
+// parâmetros começam em $1.
+type postgresFilter struct {
+	conditions []string
+	args       []any
+}
+
+func newPostgresFilter(opts ListOptions) *postgresFilter {
+	f := &postgresFilter{}
+	if !opts.IncludeDeleted {
+		f.conditions = append(f.conditions, "deleted_at IS NULL")
+	}
+	if opts.Name != "" {
+		f.add("name ILIKE $%d", "%"+escapeLike(opts.Name)+"%")
+	}
+	if opts.EmailDomain != "" {
+		f.add("email ILIKE $%d", "%@"+escapeLike(strings.TrimPrefix(opts.EmailDomain, "@")))
+	}
+	if opts.Website != "" {
+		f.add("website ILIKE $%d", "%"+escapeLike(opts.Website)+"%")
+	}
+	return f
+}
+
+// add acrescenta uma condição; cada %d em condition recebe o número do
+// próximo parâmetro, na ordem de args.
+func (f *postgresFilter) add(condition string, args ...any) {
+	placeholders := make([]any, len(args))
+	for i, arg := range args {
+		f.args = append(f.args, arg)
+		placeholders[i] = len(f.args)
+	}
+	f.conditions = append(f.conditions, fmt.Sprintf(condition, placeholders...))
+}
+
+// param acrescenta um argumento e retorna seu placeholder.
+func (f *postgresFilter) param(arg any) string {
+	f.args = append(f.args, arg)
+	return fmt.Sprintf("$%d", len(f.args))
+}
+
+func (f *postgresFilter) where() string {
+	if len(f.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(f.conditions, " AND ")
+}
+
+// escapeLike escapa os curingas de LIKE para que o valor seja buscado
+// literalmente.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// postgresOrderBy equivale a buildSort. Os textos são comparados byte a byte,
+// como no MongoDB, e não pela collation do banco.
+func postgresOrderBy(fields []SortField) string {
+	parts := make([]string, 0, len(fields)+1)
+	for _, f := range fields {
+		column := sortableFields[f.Field]
+		if column != "created_at" && column != "updated_at" {
+			column += ` COLLATE "C"`
+		}
+		if f.Desc {
+			column += " DESC"
+		}
+		parts = append(parts, column)
+	}
+	return " ORDER BY " + strings.Join(append(parts, "id"), ", ")
+}
+
+func (s *PostgresStore) List(ctx context.Context, opts ListOptions) ([]models.University, int64, error) {
+	filter := newPostgresFilter(opts)
+	db := s.db(ctx)
+
+	var total int64
+	if err := db.QueryRow(ctx, "SELECT count(*) FROM universities"+filter.where(), filter.args...).Scan(&total); err != nil {
+		return nil, 0, postgresError(err)
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+
+	query := "SELECT " + universityColumns + " FROM universities" + filter.where() + postgresOrderBy(opts.Sort)
+	query += " OFFSET " + filter.param(opts.Offset) + " LIMIT " + filter.param(limit)
+
+	rows, err := db.Query(ctx, query, filter.args...)
+	if err != nil {
+		return nil, 0, postgresError(err)
+	}
+	universities, err := scanUniversities(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return universities, total, nil
+}
+
+func (s *PostgresStore) ListByCursor(ctx context.Context, opts ListOptions, cursor string) ([]models.University, string, error) {
+	filter := newPostgresFilter(opts)
+
+	if cursor != "" {
+		after, err := decodeCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		filter.add("(created_at, id) > ($%d, $%d)", after.CreatedAt, uuidFromObjectID(after.ID))
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+
+	// Busca um registro extra apenas para saber se existe próxima página
+	query := "SELECT " + universityColumns + " FROM universities" + filter.where() +
+		" ORDER BY created_at, id LIMIT " + filter.param(limit+1)
+
+	rows, err := s.db(ctx).Query(ctx, query, filter.args...)
+	if err != nil {
+		return nil, "", postgresError(err)
+	}
+	universities, err := scanUniversities(rows)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var nextCursor string
+	if int64(len(universities)) > limit {
+		universities = universities[:limit]
+		nextCursor = encodeCursor(universities[len(universities)-1])
+	}
+	return universities, nextCursor, nil
+}
+
+func (s *PostgresStore) Each(ctx context.Context, opts ListOptions, fn func(*models.University) error) error {
+	filter := newPostgresFilter(opts)
+	query := "SELECT " + universityColumns + " FROM universities" + filter.where() + " ORDER BY id"
+	if opts.Limit > 0 {
+		query += " LIMIT " + filter.param(opts.Limit)
+	}
+
+	rows, err := s.db(ctx).Query(ctx, query, filter.args...)
+	if err != nil {
+		return postgresError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		university, err := scanUniversity(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(&university); err != nil {
+			return err
+		}
+	}
+	return postgresError(rows.Err())
+}
+
+// Search usa websearch_to_tsquery, que aceita a mesma sintaxe do $text do
+// MongoDB: frases entre aspas e termos negados com "-". Consulta e textos são
+// comparados sem acentos.
+func (s *PostgresStore) Search(ctx context.Context, query string, limit int64) ([]models.SearchHit, error) {
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+
+	// Pesos das classes D, C, B e A; name tem peso A e address peso B
+	rows, err := s.db(ctx).Query(ctx, `
+		SELECT `+universityColumns+`, ts_rank('{0, 0, 0.5, 1}'::real[], search, query) AS score
+		FROM universities, websearch_to_tsquery('portuguese', $1) AS query
+		WHERE deleted_at IS NULL AND search @@ query
+		ORDER BY score DESC, id
+		LIMIT $2`,
+		textutil.Fold(query), limit,
+	)
+	if err != nil {
+		return nil, postgresError(err)
+	}
+	defer rows.Close()
+
+	terms := textutil.Terms(query)
+	hits := make([]models.SearchHit, 0)
+	for rows.Next() {
+		var score float32
+		university, err := scanUniversity(rows, &score)
+		if err != nil {
+			return nil, err
+		}
+
+		highlights := map[string]string{}
+		if h := textutil.Highlight(university.Name, terms); h != "" {
+			highlights["name"] = h
+		}
+		if h := textutil.Highlight(university.Address, terms); h != "" {
+			highlights["address"] = h
+		}
+		hits = append(hits, models.SearchHit{University: university, Score: float64(score), Highlights: highlights})
+	}
+	return hits, postgresError(rows.Err())
+}
+
+func (s *PostgresStore) Autocomplete(ctx context.Context, prefix string, limit int64) ([]models.Suggestion, error) {
+	if limit <= 0 {
+		limit = DefaultAutocompleteLimit
+	}
+
+	// name_normalized usa a collation "C", o que permite usar o índice no LIKE
+	rows, err := s.db(ctx).Query(ctx, `
+		SELECT id, name FROM universities
+		WHERE deleted_at IS NULL AND name_normalized LIKE $1
+		ORDER BY name_normalized, id
+		LIMIT $2`,
+		escapeLike(textutil.Fold(prefix))+"%", limit,
+	)
+	if err != nil {
+		return nil, postgresError(err)
+	}
+	defer rows.Close()
+
+	suggestions := make([]models.Suggestion, 0)
+	for rows.Next() {
+		var suggestion models.Suggestion
+		var id pgtype.UUID
+		if err := rows.Scan(&id, &suggestion.Name); err != nil {
+			return nil, postgresError(err)
+		}
+		if suggestion.ID, err = objectIDFromUUID(id); err != nil {
+			return nil, err
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	return suggestions, postgresError(rows.Err())
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// No PostgreSQL os IDs ficam em colunas uuid, como UUIDv7. A API e os eventos
+// continuam usando ObjectID, então a conversão precisa ser reversível: os
+// segundos do ObjectID viram o timestamp em milissegundos do UUIDv7 e os 8
+// bytes restantes ocupam, em ordem, os bits aleatórios (rand_a e o início de
+// rand_b). Assim os UUIDs são válidos e mantêm a ordem dos ObjectIDs.
+
+// uuidFromObjectID converte o ObjectID no UUIDv7 gravado no banco.
+func uuidFromObjectID(id primitive.ObjectID) pgtype.UUID {
+	var u pgtype.UUID
+	millis := uint64(binary.BigEndian.Uint32(id[0:4])) * 1000
+	rest := binary.BigEndian.Uint64(id[4:12])
+
+	binary.BigEndian.PutUint64(u.Bytes[0:8], millis<<16)
+	u.Bytes[6] = 0x70 | byte(rest>>60)
+	u.Bytes[7] = byte(rest >> 52)
+	// Variante RFC 9562 nos dois bits mais altos; os 10 bits finais ficam zerados
+	binary.BigEndian.PutUint64(u.Bytes[8:16], 0x8000000000000000|(rest<<12)>>2)
+	u.Valid = true
+	return u
+}
+
+// objectIDFromUUID desfaz uuidFromObjectID, rejeitando UUIDs que não foram
+// gerados por ela.
+func objectIDFromUUID(u pgtype.UUID) (primitive.ObjectID, error) {
+	var id primitive.ObjectID
+	millis := binary.BigEndian.Uint64(u.Bytes[0:8]) >> 16
+	low := binary.BigEndian.Uint64(u.Bytes[8:16])
+	if !u.Valid || u.Bytes[6]>>4 != 7 || low>>62 != 2 || low&0x3ff != 0 || millis%1000 != 0 || millis/1000 > 0xffffffff {
+		return id, fmt.Errorf("%w: uuid %x is not derived from an ObjectID", ErrInvalidID, u.Bytes)
+	}
+
+	rest := uint64(u.Bytes[6]&0x0f)<<60 | uint64(u.Bytes[7])<<52 | (low&0x3fffffffffffffff)>>10
+	binary.BigEndian.PutUint32(id[0:4], uint32(millis/1000))
+	binary.BigEndian.PutUint64(id[4:12], rest)
+	return id, nil
+}
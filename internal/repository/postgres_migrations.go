@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+//go:embed migrations/postgres/*.sql
+var postgresMigrations embed.FS
+
+// postgresMigrationLock identifica o advisory lock que impede duas instâncias
+// de aplicarem as migrações ao mesmo tempo.
+const postgresMigrationLock = 7_301_948_226
+
+// Migrate aplica, em ordem, as migrações de migrations/postgres que ainda não
+// constam em schema_migrations. É idempotente e deve ser chamado na
+// inicialização do serviço, como EnsureIndexes no MongoDB.
+func (s *PostgresStore) Migrate(ctx context.Context) error {
+	names, err := fs.Glob(postgresMigrations, "migrations/postgres/*.sql")
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+
+	return s.WithTransaction(ctx, func(ctx context.Context) error {
+		db := s.db(ctx)
+
+		if _, err := db.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", postgresMigrationLock); err != nil {
+			return err
+		}
+		_, err := db.Exec(ctx, `
+			CREATE TABLE IF NOT EXISTS schema_migrations (
+				version    TEXT PRIMARY KEY,
+				applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+			)`)
+		if err != nil {
+			return err
+		}
+
+		for _, name := range names {
+			version := strings.TrimSuffix(name[strings.LastIndex(name, "/")+1:], ".sql")
+
+			var applied bool
+			err := db.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)", version).Scan(&applied)
+			if err != nil {
+				return err
+			}
+			if applied {
+				continue
+			}
+
+			script, err := postgresMigrations.ReadFile(name)
+			if err != nil {
+				return err
+			}
+			// Sem argumentos o pgx usa o protocolo simples, que aceita vários comandos
+			if _, err := db.Exec(ctx, string(script)); err != nil {
+				return fmt.Errorf("migration %s: %w", version, err)
+			}
+			if _, err := db.Exec(ctx, "INSERT INTO schema_migrations (version) VALUES ($1)", version); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
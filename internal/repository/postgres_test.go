@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// setupTestPostgres conecta ao PostgreSQL informado em POSTGRES_TEST_URL e cria
+// um schema exclusivo para o teste, removido na limpeza. Sem a variável o
+// teste é ignorado.
+func setupTestPostgres(t *testing.T) *PostgresStore {
+	url := os.Getenv("POSTGRES_TEST_URL")
+	if url == "" {
+		t.Skip("POSTGRES_TEST_URL not set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	schema := "test_" + primitive.NewObjectID().Hex()
+	admin, err := pgx.Connect(ctx, url)
+	if err != nil {
+		t.Fatalf("Failed to connect to PostgreSQL: %v", err)
+	}
+	if _, err := admin.Exec(ctx, "CREATE SCHEMA "+schema); err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+
+	config, err := pgxpool.ParseConfig(url)
+	if err != nil {
+		t.Fatalf("Invalid POSTGRES_TEST_URL: %v", err)
+	}
+	config.ConnConfig.RuntimeParams["search_path"] = schema
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		t.Fatalf("Failed to connect to PostgreSQL: %v", err)
+	}
+
+	t.Cleanup(func() {
+		pool.Close()
+		ctx := context.Background()
+		if _, err := admin.Exec(ctx, "DROP SCHEMA "+schema+" CASCADE"); err != nil {
+			t.Errorf("Failed to drop test schema: %v", err)
+		}
+		admin.Close(ctx)
+	})
+
+	store := NewPostgresStore(pool)
+	if err := store.Migrate(ctx); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	return store
+}
+
+func TestPostgresStore_Contract(t *testing.T) {
+	testUniversityStore(t, func(t *testing.T) UniversityStore {
+		return setupTestPostgres(t)
+	})
+}
+
+func TestPostgresStore_Migrate(t *testing.T) {
+	store := setupTestPostgres(t)
+
+	// Aplicar de novo não faz nada
+	assert.NoError(t, store.Migrate(context.Background()))
+}
+
+func TestPostgresFilter(t *testing.T) {
+	filter := newPostgresFilter(ListOptions{Name: "50%_off", EmailDomain: "@usp.br"})
+	assert.Equal(t, " WHERE deleted_at IS NULL AND name ILIKE $1 AND email ILIKE $2", filter.where())
+	assert.Equal(t, []any{`%50\%\_off%`, "%@usp.br"}, filter.args)
+	assert.Equal(t, "$3", filter.param(int64(10)))
+
+	filter = newPostgresFilter(ListOptions{IncludeDeleted: true})
+	assert.Equal(t, "", filter.where())
+}
+
+func TestPostgresOrderBy(t *testing.T) {
+	assert.Equal(t, " ORDER BY id", postgresOrderBy(nil))
+	assert.Equal(t, ` ORDER BY name COLLATE "C" DESC, created_at, id`,
+		postgresOrderBy([]SortField{{Field: "name", Desc: true}, {Field: "created_at"}}))
+}
+
+func TestPostgresError(t *testing.T) {
+	assert.NoError(t, postgresError(nil))
+	assert.Equal(t, ErrNotFound, postgresError(pgx.ErrNoRows))
+	assert.ErrorIs(t, postgresError(&pgconn.PgError{Code: "23505"}), ErrDuplicate)
+	assert.ErrorIs(t, postgresError(&pgconn.PgError{Code: "08006"}), ErrUnavailable)
+	assert.ErrorIs(t, postgresError(&net.OpError{Op: "dial", Err: os.ErrDeadlineExceeded}), ErrUnavailable)
+
+	syntax := &pgconn.PgError{Code: "42601"}
+	assert.Equal(t, syntax, postgresError(syntax))
+}
+
+func TestUUIDFromObjectID(t *testing.T) {
+	ids := []primitive.ObjectID{
+		primitive.NilObjectID,
+		primitive.NewObjectIDFromTimestamp(time.Unix(1700000000, 0)),
+		primitive.NewObjectID(),
+		{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+	}
+
+	var previous pgtype.UUID
+	for i, id := range ids {
+		u := uuidFromObjectID(id)
+		assert.Equal(t, byte(7), u.Bytes[6]>>4, "version")
+		assert.Equal(t, byte(2), u.Bytes[8]>>6, "variant")
+
+		back, err := objectIDFromUUID(u)
+		assert.NoError(t, err)
+		assert.Equal(t, id, back)
+
+		// A ordem dos UUIDs é a mesma dos ObjectIDs
+		if i > 0 {
+			assert.Negative(t, bytes.Compare(previous.Bytes[:], u.Bytes[:]))
+		}
+		previous = u
+	}
+
+	random := pgtype.UUID{Bytes: [16]byte{0x01, 0x8f, 0x3c, 0x4a, 0x5b, 0x6c, 0x7d, 0xee, 0x9f, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07}, Valid: true}
+	_, err := objectIDFromUUID(random)
+	assert.ErrorIs(t, err, ErrInvalidID)
+}
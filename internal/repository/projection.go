@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/university-service/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ProjectionRepository mantém o modelo de leitura construído a partir dos
+// eventos (coleção university_projection) e os IDs dos eventos já aplicados
+// (coleção projection_processed_events), usados para descartar duplicatas.
+type ProjectionRepository struct {
+	collection *mongo.Collection
+	processed  *mongo.Collection
+}
+
+func NewProjectionRepository(db *mongo.Database) *ProjectionRepository {
+	return &ProjectionRepository{
+		collection: db.Collection("university_projection"),
+		processed:  db.Collection("projection_processed_events"),
+	}
+}
+
+// EnsureIndexes cria, se retention for maior que zero, um índice TTL que
+// remove os IDs de eventos processados após esse período. Ele deve ser maior
+// que o tempo em que uma mensagem pode ser reentregue pelo Kafka.
+func (r *ProjectionRepository) EnsureIndexes(ctx context.Context, retention time.Duration) error {
+	if retention <= 0 {
+		return nil
+	}
+	_, err := r.processed.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "processed_at", Value: 1}},
+		Options: options.Index().SetName("processed_events_ttl").SetExpireAfterSeconds(int32(retention.Seconds())),
+	})
+	return err
+}
+
+// errOutdatedEvent aborta a transação de Apply quando a projeção já está numa
+// versão mais nova que a do evento.
+var errOutdatedEvent = errors.New("outdated event")
+
+// Apply aplica o evento ao modelo de leitura e retorna false se ele já tinha
+// sido aplicado antes ou se a projeção já está numa versão mais nova que a do
+// evento, como numa reentrega fora de ordem. Eventos da mesma versão são
+// aplicados, já que a remoção definitiva e o reindex não alteram a versão. O
+// registro do evento e a atualização do modelo são gravados na mesma
+// transação.
+func (r *ProjectionRepository) Apply(ctx context.Context, event *models.UniversityEvent) (bool, error) {
+	if event.University == nil || event.University.ID.IsZero() {
+		return false, errors.New("event without university")
+	}
+
+	now := time.Now()
+	id := event.University.ID
+	err := withTransaction(ctx, r.collection.Database().Client(), func(ctx context.Context) error {
+		_, err := r.processed.InsertOne(ctx, bson.M{
+			"_id":           event.ID,
+			"university_id": id,
+			"processed_at":  now,
+		})
+		if err != nil {
+			return mongoError(err)
+		}
+
+		set := bson.M{
+			"status":          models.ProjectionStatus(event),
+			"last_event_id":   event.ID,
+			"last_event_type": event.Type,
+			"last_event_at":   event.OccurredAt,
+			"updated_at":      now,
+			"university":      event.University,
+		}
+		update := bson.M{
+			"$set": set,
+			"$inc": bson.M{"event_counts." + event.Type: 1, "total_events": 1},
+		}
+		result, err := r.collection.UpdateOne(ctx, bson.M{
+			"_id": id,
+			"$or": bson.A{
+				bson.M{"university.version": bson.M{"$lte": event.University.Version}},
+				bson.M{"university.version": bson.M{"$exists": false}},
+			},
+		}, update)
+		if err != nil {
+			return mongoError(err)
+		}
+		if result.MatchedCount > 0 {
+			return nil
+		}
+
+		// Nenhuma projeção na versão do evento: ou ela ainda não existe, ou
+		// já está numa versão mais nova
+		count, err := r.collection.CountDocuments(ctx, bson.M{"_id": id})
+		if err != nil {
+			return mongoError(err)
+		}
+		if count > 0 {
+			return errOutdatedEvent
+		}
+		_, err = r.collection.UpdateOne(ctx, bson.M{"_id": id}, update, options.Update().SetUpsert(true))
+		return mongoError(err)
+	})
+	if errors.Is(err, ErrDuplicate) || errors.Is(err, errOutdatedEvent) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *ProjectionRepository) Get(ctx context.Context, id string) (*models.UniversityProjection, error) {
+	objectID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var projection models.UniversityProjection
+	if err := r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&projection); err != nil {
+		return nil, mongoError(err)
+	}
+	return &projection, nil
+}
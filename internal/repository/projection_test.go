@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/university-service/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestProjectionRepository_Apply(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewProjectionRepository(db)
+	ctx := context.Background()
+
+	uni := &models.University{ID: primitive.NewObjectID(), Name: "Test University"}
+	created := models.NewUniversityEvent(models.EventUniversityCreated, uni)
+
+	applied, err := repo.Apply(ctx, created)
+	assert.NoError(t, err)
+	assert.True(t, applied)
+
+	// Evento repetido
+	applied, err = repo.Apply(ctx, created)
+	assert.NoError(t, err)
+	assert.False(t, applied)
+
+	updated := *uni
+	updated.Name = "Updated University"
+	applied, err = repo.Apply(ctx, models.NewUniversityEvent(models.EventUniversityUpdated, &updated))
+	assert.NoError(t, err)
+	assert.True(t, applied)
+
+	projection, err := repo.Get(ctx, uni.ID.Hex())
+	assert.NoError(t, err)
+	assert.Equal(t, "Updated University", projection.University.Name)
+	assert.Equal(t, models.ProjectionActive, projection.Status)
+	assert.Equal(t, int64(2), projection.TotalEvents)
+	assert.Equal(t, int64(1), projection.EventCounts[models.EventUniversityCreated])
+	assert.Equal(t, models.EventUniversityUpdated, projection.LastEventType)
+
+	// Eventos mais antigos que a projeção são ignorados
+	v2 := *uni
+	v2.Version = 2
+	v2.Name = "Version 2"
+	applied, err = repo.Apply(ctx, models.NewUniversityEvent(models.EventUniversityUpdated, &v2))
+	assert.NoError(t, err)
+	assert.True(t, applied)
+
+	v1 := *uni
+	v1.Version = 1
+	v1.Name = "Version 1"
+	applied, err = repo.Apply(ctx, models.NewUniversityEvent(models.EventUniversityUpdated, &v1))
+	assert.NoError(t, err)
+	assert.False(t, applied)
+
+	projection, err = repo.Get(ctx, uni.ID.Hex())
+	assert.NoError(t, err)
+	assert.Equal(t, "Version 2", projection.University.Name)
+	assert.Equal(t, int64(2), projection.University.Version)
+	assert.Equal(t, int64(3), projection.TotalEvents)
+
+	_, err = repo.Get(ctx, "invalid")
+	assert.ErrorIs(t, err, ErrInvalidID)
+	_, err = repo.Get(ctx, primitive.NewObjectID().Hex())
+	assert.ErrorIs(t, err, ErrNotFound)
+}
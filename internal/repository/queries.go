@@ -0,0 +1,255 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/university-service/internal/models"
+	"github.com/university-service/internal/textutil"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// findFunc retorna, em ordem de _id, os registros que atendem aos filtros de
+// opts, ignorando a ordenação e a paginação.
+type findFunc func(ctx context.Context, opts ListOptions) ([]models.University, error)
+
+// scanQueries implementa as consultas de UniversityStore percorrendo os
+// registros retornados por um findFunc, com as mesmas regras do
+// UniversityRepository. É usado pelos backends sem índices próprios.
+type scanQueries struct {
+	find findFunc
+}
+
+// matchesListOptions reproduz os filtros de buildListFilter.
+func matchesListOptions(university models.University, opts ListOptions) bool {
+	if !opts.IncludeDeleted && university.DeletedAt != nil {
+		return false
+	}
+	if opts.Name != "" && !containsFold(university.Name, opts.Name) {
+		return false
+	}
+	if opts.EmailDomain != "" {
+		domain := "@" + strings.ToLower(strings.TrimPrefix(opts.EmailDomain, "@"))
+		if !strings.HasSuffix(strings.ToLower(university.Email), domain) {
+			return false
+		}
+	}
+	if opts.Website != "" && !containsFold(university.Website, opts.Website) {
+		return false
+	}
+	return true
+}
+
+func compareIDs(a, b primitive.ObjectID) int {
+	return strings.Compare(a.Hex(), b.Hex())
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+func (q scanQueries) List(ctx context.Context, opts ListOptions) ([]models.University, int64, error) {
+	universities, err := q.find(ctx, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	total := int64(len(universities))
+
+	// find já deixa os registros em ordem de _id, o critério de desempate
+	sort.SliceStable(universities, func(i, j int) bool {
+		for _, field := range opts.Sort {
+			c := compareField(universities[i], universities[j], field.Field)
+			if c == 0 {
+				continue
+			}
+			if field.Desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	return page(universities, opts.Offset, limit), total, nil
+}
+
+func compareField(a, b models.University, field string) int {
+	switch field {
+	case "name":
+		return strings.Compare(a.Name, b.Name)
+	case "email":
+		return strings.Compare(a.Email, b.Email)
+	case "website":
+		return strings.Compare(a.Website, b.Website)
+	case "created_at":
+		return a.CreatedAt.Compare(b.CreatedAt)
+	case "updated_at":
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	}
+	return 0
+}
+
+func page[T any](items []T, offset, limit int64) []T {
+	if offset >= int64(len(items)) {
+		return items[:0]
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	items = items[offset:]
+	if limit > 0 && limit < int64(len(items)) {
+		items = items[:limit]
+	}
+	return items
+}
+
+func (q scanQueries) ListByCursor(ctx context.Context, opts ListOptions, cursor string) ([]models.University, string, error) {
+	var after *pageCursor
+	if cursor != "" {
+		var err error
+		if after, err = decodeCursor(cursor); err != nil {
+			return nil, "", err
+		}
+	}
+
+	universities, err := q.find(ctx, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	sort.SliceStable(universities, func(i, j int) bool {
+		return universities[i].CreatedAt.Before(universities[j].CreatedAt)
+	})
+	if after != nil {
+		start := sort.Search(len(universities), func(i int) bool {
+			c := universities[i].CreatedAt.Compare(after.CreatedAt)
+			return c > 0 || (c == 0 && compareIDs(universities[i].ID, after.ID) > 0)
+		})
+		universities = universities[start:]
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+
+	var nextCursor string
+	if int64(len(universities)) > limit {
+		universities = universities[:limit]
+		nextCursor = encodeCursor(universities[len(universities)-1])
+	}
+	return universities, nextCursor, nil
+}
+
+func (q scanQueries) Each(ctx context.Context, opts ListOptions, fn func(*models.University) error) error {
+	universities, err := q.find(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if opts.Limit > 0 {
+		universities = page(universities, 0, opts.Limit)
+	}
+
+	for i := range universities {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(&universities[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Search aproxima a busca textual do MongoDB: um termo casa com as palavras
+// de name e address que começam com ele, sem diferenciar maiúsculas nem
+// acentos, e termos com "-" excluem o registro. A relevância soma os pesos do
+// índice de texto (10 para name, 5 para address) de cada palavra encontrada.
+func (q scanQueries) Search(ctx context.Context, query string, limit int64) ([]models.SearchHit, error) {
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+
+	terms := textutil.Terms(query)
+	var excluded []string
+	for _, field := range strings.Fields(query) {
+		if strings.HasPrefix(field, "-") && len(field) > 1 {
+			excluded = append(excluded, field[1:])
+		}
+	}
+
+	universities, err := q.find(ctx, ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]models.SearchHit, 0)
+	for _, university := range universities {
+		if countMatches(university.Name, excluded)+countMatches(university.Address, excluded) > 0 {
+			continue
+		}
+		score := float64(10*countMatches(university.Name, terms) + 5*countMatches(university.Address, terms))
+		if score == 0 {
+			continue
+		}
+
+		highlights := map[string]string{}
+		if h := textutil.Highlight(university.Name, terms); h != "" {
+			highlights["name"] = h
+		}
+		if h := textutil.Highlight(university.Address, terms); h != "" {
+			highlights["address"] = h
+		}
+		hits = append(hits, models.SearchHit{University: university, Score: score, Highlights: highlights})
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Score > hits[j].Score
+	})
+	return page(hits, 0, limit), nil
+}
+
+// countMatches conta as palavras de text que começam com algum dos termos.
+func countMatches(text string, terms []string) int {
+	count := 0
+	for _, word := range strings.FieldsFunc(textutil.Fold(text), isSeparator) {
+		for _, term := range terms {
+			if needle := textutil.Fold(term); needle != "" && strings.HasPrefix(word, needle) {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+func isSeparator(r rune) bool {
+	return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r > 0x7f)
+}
+
+func (q scanQueries) Autocomplete(ctx context.Context, prefix string, limit int64) ([]models.Suggestion, error) {
+	if limit <= 0 {
+		limit = DefaultAutocompleteLimit
+	}
+
+	universities, err := q.find(ctx, ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	folded := textutil.Fold(prefix)
+	suggestions := make([]models.Suggestion, 0)
+	sort.SliceStable(universities, func(i, j int) bool {
+		return universities[i].NameNormalized < universities[j].NameNormalized
+	})
+	for _, university := range universities {
+		if strings.HasPrefix(university.NameNormalized, folded) {
+			suggestions = append(suggestions, models.Suggestion{ID: university.ID, Name: university.Name})
+		}
+	}
+	return page(suggestions, 0, limit), nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/university-service/internal/models"
+	"github.com/university-service/internal/textutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type actorKey struct{}
+
+// WithActor associa ao contexto o identificador de quem está fazendo a
+// alteração, que é gravado nas revisões.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func actorFrom(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+func (r *UniversityRepository) recordRevision(ctx context.Context, previous *models.University, action string) error {
+	_, err := r.revisions.InsertOne(ctx, models.Revision{
+		UniversityID: previous.ID,
+		Revision:     previous.Version,
+		Action:       action,
+		ChangedBy:    actorFrom(ctx),
+		ChangedAt:    time.Now(),
+		Snapshot:     *previous,
+	})
+	return mongoError(err)
+}
+
+// ListRevisions retorna as versões anteriores de uma universidade, da mais
+// antiga para a mais recente.
+func (r *UniversityRepository) ListRevisions(ctx context.Context, id string) ([]models.Revision, error) {
+	objectID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := r.revisions.Find(ctx,
+		bson.M{"university_id": objectID},
+		options.Find().SetSort(bson.D{{Key: "revision", Value: 1}, {Key: "_id", Value: 1}}),
+	)
+	if err != nil {
+		return nil, mongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	revisions := make([]models.Revision, 0)
+	if err = cursor.All(ctx, &revisions); err != nil {
+		return nil, mongoError(err)
+	}
+
+	return revisions, nil
+}
+
+func (r *UniversityRepository) GetRevision(ctx context.Context, id string, revision int64) (*models.Revision, error) {
+	objectID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.Revision
+	err = r.revisions.FindOne(ctx, bson.M{"university_id": objectID, "revision": revision}).Decode(&result)
+	if err != nil {
+		return nil, mongoError(err)
+	}
+
+	return &result, nil
+}
+
+// Revert grava university (normalmente o snapshot de uma revisão anterior)
+// como estado atual, inclusive a situação de exclusão lógica do snapshot. Assim
+// como Update, exige que o documento esteja em university.Version.
+func (r *UniversityRepository) Revert(ctx context.Context, university *models.University) error {
+	expectedVersion := university.Version
+	university.UpdatedAt = time.Now()
+	university.NameNormalized = textutil.Fold(university.Name)
+	university.Version = expectedVersion + 1
+
+	update := bson.M{"$set": university}
+	if university.DeletedAt == nil {
+		update["$unset"] = bson.M{"deleted_at": ""}
+	}
+
+	err := r.updateAndRecord(ctx, university.ID, versionFilter(university.ID, expectedVersion), update, models.RevisionReverted)
+	if err != nil {
+		university.Version = expectedVersion
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/university-service/internal/models"
+	"github.com/university-service/internal/textutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	DefaultSearchLimit       int64 = 20
+	DefaultAutocompleteLimit int64 = 10
+	MaxAutocompleteLimit     int64 = 50
+)
+
+// EnsureIndexes cria os índices usados pelas consultas do repositório. É
+// idempotente e deve ser chamado na inicialização do serviço.
+func (r *UniversityRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "name", Value: "text"}, {Key: "address", Value: "text"}},
+			Options: options.Index().
+				SetName("universities_text").
+				SetWeights(bson.D{{Key: "name", Value: 10}, {Key: "address", Value: 5}}).
+				SetDefaultLanguage("portuguese"),
+		},
+		{
+			Keys:    bson.D{{Key: "name_normalized", Value: 1}},
+			Options: options.Index().SetName("universities_name_normalized"),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = r.revisions.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "university_id", Value: 1}, {Key: "revision", Value: 1}},
+		Options: options.Index().SetName("university_revisions_revision").SetUnique(true),
+	})
+	return err
+}
+
+// backfillBatchSize é o número de documentos atualizados por BulkWrite em
+// BackfillNormalizedNames.
+const backfillBatchSize = 1000
+
+// BackfillNormalizedNames preenche name_normalized nos documentos gravados
+// antes da existência do campo. O nome é normalizado em Go (textutil.Fold),
+// então os documentos são lidos e atualizados em lotes.
+func (r *UniversityRepository) BackfillNormalizedNames(ctx context.Context) error {
+	cursor, err := r.collection.Find(ctx,
+		bson.M{"name_normalized": bson.M{"$exists": false}},
+		options.Find().SetProjection(bson.M{"name": 1}),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	updates := make([]mongo.WriteModel, 0, backfillBatchSize)
+	flush := func() error {
+		if len(updates) == 0 {
+			return nil
+		}
+		_, err := r.collection.BulkWrite(ctx, updates, options.BulkWrite().SetOrdered(false))
+		updates = updates[:0]
+		return err
+	}
+
+	for cursor.Next(ctx) {
+		var doc models.Suggestion
+		if err := cursor.Decode(&doc); err != nil {
+			return err
+		}
+		updates = append(updates, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": doc.ID}).
+			SetUpdate(bson.M{"$set": bson.M{"name_normalized": textutil.Fold(doc.Name)}}))
+		if len(updates) == backfillBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+	return flush()
+}
+
+type searchDocument struct {
+	models.University `bson:",inline"`
+	Score             float64 `bson:"score"`
+}
+
+// Search executa uma busca textual em name e address, ordenada por relevância.
+func (r *UniversityRepository) Search(ctx context.Context, query string, limit int64) ([]models.SearchHit, error) {
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+
+	filter := bson.M{"$text": bson.M{"$search": query}, "deleted_at": nil}
+	findOptions := options.Find().
+		SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}}).
+		SetSort(bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}, {Key: "_id", Value: 1}}).
+		SetLimit(limit)
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, mongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	var documents []searchDocument
+	if err = cursor.All(ctx, &documents); err != nil {
+		return nil, mongoError(err)
+	}
+
+	terms := textutil.Terms(query)
+	hits := make([]models.SearchHit, 0, len(documents))
+	for _, doc := range documents {
+		highlights := map[string]string{}
+		if h := textutil.Highlight(doc.Name, terms); h != "" {
+			highlights["name"] = h
+		}
+		if h := textutil.Highlight(doc.Address, terms); h != "" {
+			highlights["address"] = h
+		}
+		hits = append(hits, models.SearchHit{
+			University: doc.University,
+			Score:      doc.Score,
+			Highlights: highlights,
+		})
+	}
+
+	return hits, nil
+}
+
+// Autocomplete retorna as universidades cujo nome começa com o prefixo
+// informado, sem diferenciar maiúsculas nem acentos.
+func (r *UniversityRepository) Autocomplete(ctx context.Context, prefix string, limit int64) ([]models.Suggestion, error) {
+	if limit <= 0 {
+		limit = DefaultAutocompleteLimit
+	}
+
+	// Regex ancorada no início usa o índice de name_normalized
+	filter := bson.M{
+		"name_normalized": bson.M{"$regex": "^" + regexp.QuoteMeta(textutil.Fold(prefix))},
+		"deleted_at":      nil,
+	}
+	findOptions := options.Find().
+		SetProjection(bson.M{"name": 1}).
+		SetSort(bson.D{{Key: "name_normalized", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(limit)
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, mongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	suggestions := make([]models.Suggestion, 0)
+	if err = cursor.All(ctx, &suggestions); err != nil {
+		return nil, mongoError(err)
+	}
+
+	return suggestions, nil
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/university-service/internal/models"
+)
+
+// UniversityStore é o armazenamento de universidades usado pela API e pelos
+// serviços. UniversityRepository (MongoDB), PostgresStore, BoltStore e
+// MemoryStore o implementam com a mesma semântica: IDs atribuídos na criação,
+// controle de versão, exclusão lógica, revisões e os erros de errors.go no
+// lugar dos erros de cada driver.
+type UniversityStore interface {
+	Create(ctx context.Context, university *models.University) error
+	GetByID(ctx context.Context, id string) (*models.University, error)
+	GetByIDIncludingDeleted(ctx context.Context, id string) (*models.University, error)
+	List(ctx context.Context, opts ListOptions) ([]models.University, int64, error)
+	ListByCursor(ctx context.Context, opts ListOptions, cursor string) ([]models.University, string, error)
+	Each(ctx context.Context, opts ListOptions, fn func(*models.University) error) error
+	Search(ctx context.Context, query string, limit int64) ([]models.SearchHit, error)
+	Autocomplete(ctx context.Context, prefix string, limit int64) ([]models.Suggestion, error)
+
+	Update(ctx context.Context, university *models.University) error
+	UpdateFields(ctx context.Context, university *models.University, fields []string) error
+	Delete(ctx context.Context, university *models.University) error
+	Restore(ctx context.Context, university *models.University) error
+	Revert(ctx context.Context, university *models.University) error
+	Purge(ctx context.Context, deletedBefore time.Time) ([]models.University, error)
+
+	ListRevisions(ctx context.Context, id string) ([]models.Revision, error)
+	GetRevision(ctx context.Context, id string, revision int64) (*models.Revision, error)
+
+	// WithTransaction executa fn de forma que as gravações feitas com o
+	// contexto recebido sejam confirmadas ou desfeitas em conjunto.
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+var (
+	_ UniversityStore = (*UniversityRepository)(nil)
+	_ UniversityStore = (*MemoryStore)(nil)
+	_ UniversityStore = (*PostgresStore)(nil)
+	_ UniversityStore = (*BoltStore)(nil)
+)
@@ -0,0 +1,528 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/university-service/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// testUniversityStore é a suíte de conformidade que toda implementação de
+// UniversityStore deve passar. newStore deve retornar um armazenamento vazio a
+// cada chamada.
+func testUniversityStore(t *testing.T, newStore func(t *testing.T) UniversityStore) {
+	ctx := context.Background()
+
+	newUniversity := func(name string) *models.University {
+		return &models.University{
+			Name:    name,
+			Address: "Rua Teste, 123",
+			Phone:   "(11) 1234-5678",
+			Email:   "contato@teste.edu.br",
+			Website: "https://teste.edu.br",
+		}
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		store := newStore(t)
+
+		uni := newUniversity("Universidade de São Paulo")
+		require.NoError(t, store.Create(ctx, uni))
+		assert.False(t, uni.ID.IsZero())
+		assert.Equal(t, int64(1), uni.Version)
+
+		found, err := store.GetByID(ctx, uni.ID.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, uni.ID, found.ID)
+		assert.Equal(t, uni.Name, found.Name)
+		assert.Equal(t, uni.Email, found.Email)
+		assert.Equal(t, "universidade de sao paulo", found.NameNormalized)
+		assert.Equal(t, int64(1), found.Version)
+		assert.Nil(t, found.DeletedAt)
+	})
+
+	t.Run("Timestamps", func(t *testing.T) {
+		store := newStore(t)
+
+		before := time.Now()
+		uni := newUniversity("Timestamps")
+		require.NoError(t, store.Create(ctx, uni))
+		assert.WithinDuration(t, before, uni.CreatedAt, time.Second)
+		assert.WithinDuration(t, uni.CreatedAt, uni.UpdatedAt, time.Millisecond)
+
+		// Os backends podem guardar os instantes com precisão de milissegundos
+		found, err := store.GetByID(ctx, uni.ID.Hex())
+		require.NoError(t, err)
+		assert.WithinDuration(t, uni.CreatedAt, found.CreatedAt, time.Millisecond)
+		assert.WithinDuration(t, uni.UpdatedAt, found.UpdatedAt, time.Millisecond)
+
+		time.Sleep(10 * time.Millisecond)
+		found.Address = "Outro Endereço"
+		require.NoError(t, store.Update(ctx, found))
+
+		updated, err := store.GetByID(ctx, uni.ID.Hex())
+		require.NoError(t, err)
+		assert.True(t, updated.UpdatedAt.After(updated.CreatedAt))
+		assert.WithinDuration(t, uni.CreatedAt, updated.CreatedAt, time.Millisecond)
+
+		time.Sleep(10 * time.Millisecond)
+		require.NoError(t, store.Delete(ctx, updated))
+		require.NotNil(t, updated.DeletedAt)
+
+		deleted, err := store.GetByIDIncludingDeleted(ctx, uni.ID.Hex())
+		require.NoError(t, err)
+		require.NotNil(t, deleted.DeletedAt)
+		assert.WithinDuration(t, *updated.DeletedAt, *deleted.DeletedAt, time.Millisecond)
+		assert.True(t, deleted.UpdatedAt.After(updated.CreatedAt))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		store := newStore(t)
+		missing := primitive.NewObjectID()
+
+		_, err := store.GetByID(ctx, missing.Hex())
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		_, err = store.GetByIDIncludingDeleted(ctx, missing.Hex())
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		_, err = store.GetRevision(ctx, missing.Hex(), 1)
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		revisions, err := store.ListRevisions(ctx, missing.Hex())
+		assert.NoError(t, err)
+		assert.Empty(t, revisions)
+
+		uni := newUniversity("Missing")
+		uni.ID = missing
+		uni.Version = 1
+		assert.ErrorIs(t, store.Update(ctx, uni), ErrNotFound)
+		assert.ErrorIs(t, store.UpdateFields(ctx, uni, []string{"name"}), ErrNotFound)
+		assert.ErrorIs(t, store.Delete(ctx, uni), ErrNotFound)
+		assert.ErrorIs(t, store.Restore(ctx, uni), ErrNotFound)
+		assert.Equal(t, int64(1), uni.Version)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		store := newStore(t)
+
+		for _, id := range []string{"", "invalid", "123", "zzzzzzzzzzzzzzzzzzzzzzzz"} {
+			_, err := store.GetByID(ctx, id)
+			assert.ErrorIs(t, err, ErrInvalidID, id)
+			assert.NotErrorIs(t, err, ErrNotFound, id)
+
+			_, err = store.GetByIDIncludingDeleted(ctx, id)
+			assert.ErrorIs(t, err, ErrInvalidID, id)
+
+			_, err = store.ListRevisions(ctx, id)
+			assert.ErrorIs(t, err, ErrInvalidID, id)
+
+			_, err = store.GetRevision(ctx, id, 1)
+			assert.ErrorIs(t, err, ErrInvalidID, id)
+		}
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		store := newStore(t)
+
+		uni := newUniversity("Original")
+		require.NoError(t, store.Create(ctx, uni))
+
+		duplicate := newUniversity("Duplicada")
+		duplicate.ID = uni.ID
+		assert.ErrorIs(t, store.Create(ctx, duplicate), ErrDuplicate)
+
+		found, err := store.GetByID(ctx, uni.ID.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, "Original", found.Name)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		store := newStore(t)
+
+		uni := newUniversity("Nome Antigo")
+		require.NoError(t, store.Create(ctx, uni))
+
+		uni.Name = "Nome Novo"
+		require.NoError(t, store.Update(WithActor(ctx, "auditor"), uni))
+		assert.Equal(t, int64(2), uni.Version)
+
+		found, err := store.GetByID(ctx, uni.ID.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, "Nome Novo", found.Name)
+		assert.Equal(t, "nome novo", found.NameNormalized)
+		assert.Equal(t, int64(2), found.Version)
+
+		revisions, err := store.ListRevisions(ctx, uni.ID.Hex())
+		require.NoError(t, err)
+		require.Len(t, revisions, 1)
+		assert.Equal(t, int64(1), revisions[0].Revision)
+		assert.Equal(t, models.RevisionUpdated, revisions[0].Action)
+		assert.Equal(t, "auditor", revisions[0].ChangedBy)
+		assert.Equal(t, "Nome Antigo", revisions[0].Snapshot.Name)
+
+		// Versão desatualizada
+		stale := *found
+		stale.Version = 1
+		assert.ErrorIs(t, store.Update(ctx, &stale), ErrVersionConflict)
+		assert.ErrorIs(t, store.Update(ctx, &stale), ErrConflict)
+		assert.Equal(t, int64(1), stale.Version)
+	})
+
+	t.Run("UpdateFields", func(t *testing.T) {
+		store := newStore(t)
+
+		uni := newUniversity("Parcial")
+		require.NoError(t, store.Create(ctx, uni))
+
+		patched := *uni
+		patched.Name = "Parcial Atualizada"
+		patched.Phone = "(21) 0000-0000"
+		require.NoError(t, store.UpdateFields(ctx, &patched, []string{"name"}))
+		assert.Equal(t, int64(2), patched.Version)
+
+		found, err := store.GetByID(ctx, uni.ID.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, "Parcial Atualizada", found.Name)
+		assert.Equal(t, "parcial atualizada", found.NameNormalized)
+		assert.Equal(t, uni.Phone, found.Phone)
+		assert.Equal(t, int64(2), found.Version)
+
+		err = store.UpdateFields(ctx, found, []string{"unknown"})
+		assert.Error(t, err)
+		assert.Equal(t, int64(2), found.Version)
+	})
+
+	t.Run("DeleteRestorePurge", func(t *testing.T) {
+		store := newStore(t)
+
+		first := newUniversity("Primeira")
+		second := newUniversity("Segunda")
+		require.NoError(t, store.Create(ctx, first))
+		require.NoError(t, store.Create(ctx, second))
+
+		require.NoError(t, store.Delete(ctx, first))
+		assert.Equal(t, int64(2), first.Version)
+
+		_, err := store.GetByID(ctx, first.ID.Hex())
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		// Registros excluídos não podem ser atualizados, mesmo na versão atual
+		deleted, err := store.GetByIDIncludingDeleted(ctx, first.ID.Hex())
+		require.NoError(t, err)
+		deleted.DeletedAt = nil
+		assert.ErrorIs(t, store.Update(ctx, deleted), ErrNotFound)
+		deleted.Name = "Renamed"
+		assert.ErrorIs(t, store.UpdateFields(ctx, deleted, []string{"name"}), ErrNotFound)
+
+		universities, total, err := store.List(ctx, ListOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		require.Len(t, universities, 1)
+		assert.Equal(t, second.ID, universities[0].ID)
+
+		_, total, err = store.List(ctx, ListOptions{IncludeDeleted: true})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+
+		require.NoError(t, store.Restore(ctx, first))
+		assert.Nil(t, first.DeletedAt)
+		restored, err := store.GetByID(ctx, first.ID.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), restored.Version)
+
+		// Apenas o que foi excluído antes do corte é removido
+		require.NoError(t, store.Delete(ctx, first))
+		time.Sleep(10 * time.Millisecond)
+		cutoff := time.Now()
+		time.Sleep(10 * time.Millisecond)
+		require.NoError(t, store.Delete(ctx, second))
+
+		purged, err := store.Purge(ctx, cutoff)
+		require.NoError(t, err)
+		require.Len(t, purged, 1)
+		assert.Equal(t, first.ID, purged[0].ID)
+
+		_, err = store.GetByIDIncludingDeleted(ctx, first.ID.Hex())
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = store.GetByIDIncludingDeleted(ctx, second.ID.Hex())
+		assert.NoError(t, err)
+
+		revisions, err := store.ListRevisions(ctx, first.ID.Hex())
+		require.NoError(t, err)
+		require.NotEmpty(t, revisions)
+		assert.Equal(t, models.RevisionPurged, revisions[len(revisions)-1].Action)
+	})
+
+	t.Run("Revert", func(t *testing.T) {
+		store := newStore(t)
+
+		uni := newUniversity("Original")
+		require.NoError(t, store.Create(ctx, uni))
+		uni.Name = "Alterada"
+		require.NoError(t, store.Update(ctx, uni))
+		require.NoError(t, store.Delete(ctx, uni))
+
+		revision, err := store.GetRevision(ctx, uni.ID.Hex(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, uni.ID, revision.UniversityID)
+
+		reverted := revision.Snapshot
+		reverted.Version = uni.Version
+		require.NoError(t, store.Revert(ctx, &reverted))
+
+		current, err := store.GetByID(ctx, uni.ID.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, "Original", current.Name)
+		assert.Equal(t, int64(4), current.Version)
+		assert.Nil(t, current.DeletedAt)
+
+		revisions, err := store.ListRevisions(ctx, uni.ID.Hex())
+		require.NoError(t, err)
+		require.Len(t, revisions, 3)
+		for i, action := range []string{models.RevisionUpdated, models.RevisionDeleted, models.RevisionReverted} {
+			assert.Equal(t, int64(i+1), revisions[i].Revision)
+			assert.Equal(t, action, revisions[i].Action)
+		}
+	})
+
+	t.Run("ConcurrentUpdates", func(t *testing.T) {
+		store := newStore(t)
+
+		uni := newUniversity("Concorrente")
+		require.NoError(t, store.Create(ctx, uni))
+
+		const writers = 8
+		var wg sync.WaitGroup
+		errs := make(chan error, writers)
+		for i := 0; i < writers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				stale := *uni
+				errs <- store.Update(ctx, &stale)
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		succeeded := 0
+		for err := range errs {
+			if err == nil {
+				succeeded++
+				continue
+			}
+			assert.ErrorIs(t, err, ErrVersionConflict)
+		}
+		assert.Equal(t, 1, succeeded)
+
+		found, err := store.GetByID(ctx, uni.ID.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), found.Version)
+	})
+
+	t.Run("ConcurrentCreates", func(t *testing.T) {
+		store := newStore(t)
+
+		const writers = 20
+		var wg sync.WaitGroup
+		ids := make(chan primitive.ObjectID, writers)
+		for i := 0; i < writers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				uni := newUniversity("Paralela")
+				if assert.NoError(t, store.Create(ctx, uni)) {
+					ids <- uni.ID
+				}
+			}()
+		}
+		wg.Wait()
+		close(ids)
+
+		unique := map[primitive.ObjectID]bool{}
+		for id := range ids {
+			unique[id] = true
+		}
+		assert.Len(t, unique, writers)
+
+		_, total, err := store.List(ctx, ListOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(writers), total)
+	})
+
+	t.Run("ListOrdering", func(t *testing.T) {
+		store := newStore(t)
+
+		for _, name := range []string{"Charlie", "Alpha", "Echo", "Bravo", "Delta", "Alpha"} {
+			require.NoError(t, store.Create(ctx, newUniversity(name)))
+		}
+
+		names := func(universities []models.University) []string {
+			result := make([]string, 0, len(universities))
+			for _, u := range universities {
+				result = append(result, u.Name)
+			}
+			return result
+		}
+
+		universities, total, err := store.List(ctx, ListOptions{Sort: []SortField{{Field: "name"}}, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), total)
+		assert.Equal(t, []string{"Alpha", "Alpha", "Bravo", "Charlie", "Delta", "Echo"}, names(universities))
+		// Empates são desfeitos pelo ID
+		assert.Less(t, universities[0].ID.Hex(), universities[1].ID.Hex())
+
+		universities, total, err = store.List(ctx, ListOptions{Sort: []SortField{{Field: "name", Desc: true}}, Offset: 1, Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), total)
+		assert.Equal(t, []string{"Delta", "Charlie"}, names(universities))
+
+		universities, _, err = store.List(ctx, ListOptions{Offset: 10})
+		require.NoError(t, err)
+		assert.Empty(t, universities)
+
+		// Sem ordenação explícita, a ordem é a dos IDs
+		universities, _, err = store.List(ctx, ListOptions{})
+		require.NoError(t, err)
+		require.Len(t, universities, 6)
+		for i := 1; i < len(universities); i++ {
+			assert.Less(t, universities[i-1].ID.Hex(), universities[i].ID.Hex())
+		}
+	})
+
+	t.Run("ListFilters", func(t *testing.T) {
+		store := newStore(t)
+
+		usp := newUniversity("Universidade de São Paulo")
+		usp.Email = "contato@usp.br"
+		usp.Website = "https://www5.usp.br"
+		unicamp := newUniversity("Universidade Estadual de Campinas")
+		unicamp.Email = "contato@UNICAMP.br"
+		unicamp.Website = "https://www.unicamp.br"
+		require.NoError(t, store.Create(ctx, usp))
+		require.NoError(t, store.Create(ctx, unicamp))
+
+		for _, tc := range []struct {
+			opts ListOptions
+			want []primitive.ObjectID
+		}{
+			{ListOptions{Name: "são paulo"}, []primitive.ObjectID{usp.ID}},
+			{ListOptions{Name: "UNIVERSIDADE"}, []primitive.ObjectID{usp.ID, unicamp.ID}},
+			{ListOptions{EmailDomain: "unicamp.br"}, []primitive.ObjectID{unicamp.ID}},
+			{ListOptions{EmailDomain: "@usp.br"}, []primitive.ObjectID{usp.ID}},
+			{ListOptions{EmailDomain: "p.br"}, []primitive.ObjectID{}},
+			{ListOptions{Website: "WWW5"}, []primitive.ObjectID{usp.ID}},
+		} {
+			universities, total, err := store.List(ctx, tc.opts)
+			require.NoError(t, err)
+			ids := make([]primitive.ObjectID, 0, len(universities))
+			for _, u := range universities {
+				ids = append(ids, u.ID)
+			}
+			assert.Equal(t, tc.want, ids, "%+v", tc.opts)
+			assert.Equal(t, int64(len(tc.want)), total, "%+v", tc.opts)
+		}
+	})
+
+	t.Run("ListByCursor", func(t *testing.T) {
+		store := newStore(t)
+
+		for i := 0; i < 5; i++ {
+			require.NoError(t, store.Create(ctx, newUniversity("Cursor")))
+		}
+
+		var seen []models.University
+		cursor := ""
+		for pages := 0; ; pages++ {
+			require.Less(t, pages, 5, "cursor pagination did not finish")
+
+			universities, next, err := store.ListByCursor(ctx, ListOptions{Limit: 2}, cursor)
+			require.NoError(t, err)
+			assert.LessOrEqual(t, len(universities), 2)
+			seen = append(seen, universities...)
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+
+		require.Len(t, seen, 5)
+		for i := 1; i < len(seen); i++ {
+			prev, cur := seen[i-1], seen[i]
+			ordered := prev.CreatedAt.Before(cur.CreatedAt) ||
+				prev.CreatedAt.Equal(cur.CreatedAt) && prev.ID.Hex() < cur.ID.Hex()
+			assert.True(t, ordered, "page order broken at %d", i)
+		}
+
+		_, _, err := store.ListByCursor(ctx, ListOptions{}, "not-a-cursor")
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+
+	t.Run("Each", func(t *testing.T) {
+		store := newStore(t)
+
+		for _, name := range []string{"Each A", "Each B", "Other"} {
+			require.NoError(t, store.Create(ctx, newUniversity(name)))
+		}
+
+		var names []string
+		err := store.Each(ctx, ListOptions{Name: "each"}, func(u *models.University) error {
+			names = append(names, u.Name)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Each A", "Each B"}, names)
+
+		count := 0
+		err = store.Each(ctx, ListOptions{Limit: 1}, func(u *models.University) error {
+			count++
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("SearchAndAutocomplete", func(t *testing.T) {
+		store := newStore(t)
+
+		federal := newUniversity("Universidade Federal do Paraná")
+		federal.Address = "Curitiba"
+		curitiba := newUniversity("Universidade de Curitiba")
+		deleted := newUniversity("Faculdade de Curitiba")
+		require.NoError(t, store.Create(ctx, federal))
+		require.NoError(t, store.Create(ctx, curitiba))
+		require.NoError(t, store.Create(ctx, deleted))
+		require.NoError(t, store.Delete(ctx, deleted))
+
+		hits, err := store.Search(ctx, "curitiba", 10)
+		require.NoError(t, err)
+		require.Len(t, hits, 2)
+		// Ocorrências no nome pesam mais que no endereço
+		assert.Equal(t, curitiba.ID, hits[0].University.ID)
+		assert.Equal(t, "Universidade de <em>Curitiba</em>", hits[0].Highlights["name"])
+		assert.Equal(t, federal.ID, hits[1].University.ID)
+		assert.Equal(t, "<em>Curitiba</em>", hits[1].Highlights["address"])
+		assert.Greater(t, hits[0].Score, hits[1].Score)
+
+		hits, err = store.Search(ctx, "parana", 10)
+		require.NoError(t, err)
+		require.Len(t, hits, 1)
+		assert.Equal(t, federal.ID, hits[0].University.ID)
+
+		suggestions, err := store.Autocomplete(ctx, "UNIVERSIDADE", 10)
+		require.NoError(t, err)
+		require.Len(t, suggestions, 2)
+		assert.Equal(t, curitiba.ID, suggestions[0].ID)
+		assert.Equal(t, federal.Name, suggestions[1].Name)
+
+		suggestions, err = store.Autocomplete(ctx, "universidade", 1)
+		require.NoError(t, err)
+		assert.Len(t, suggestions, 1)
+	})
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/university-service/internal/models"
+	"github.com/university-service/internal/textutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// updateFunc aplica apply ao registro id se ele estiver na versão esperada,
+// gravando o resultado e o estado anterior como revisão da ação informada. Deve
+// retornar ErrNotFound se o registro não existir ou se estiver excluído
+// logicamente e includeDeleted for false, e ErrVersionConflict se a versão não
+// conferir.
+type updateFunc func(ctx context.Context, id primitive.ObjectID, expectedVersion int64, includeDeleted bool, action string, apply func(models.University) (models.University, error)) error
+
+// versionedWrites implementa as alterações de UniversityStore sobre um
+// updateFunc, com as mesmas regras do UniversityRepository. É usado pelos
+// backends que não são o MongoDB.
+type versionedWrites struct {
+	update updateFunc
+}
+
+func (w versionedWrites) Update(ctx context.Context, university *models.University) error {
+	expectedVersion := university.Version
+	university.UpdatedAt = time.Now()
+	university.NameNormalized = textutil.Fold(university.Name)
+	university.Version = expectedVersion + 1
+
+	err := w.update(ctx, university.ID, expectedVersion, false, models.RevisionUpdated, func(current models.University) (models.University, error) {
+		return *university, nil
+	})
+	if err != nil {
+		university.Version = expectedVersion
+		return err
+	}
+	return nil
+}
+
+func (w versionedWrites) UpdateFields(ctx context.Context, university *models.University, fields []string) error {
+	expectedVersion := university.Version
+	university.UpdatedAt = time.Now()
+	university.NameNormalized = textutil.Fold(university.Name)
+	university.Version = expectedVersion + 1
+
+	err := w.update(ctx, university.ID, expectedVersion, false, models.RevisionPatched, func(current models.University) (models.University, error) {
+		return setFields(current, university, fields)
+	})
+	if err != nil {
+		university.Version = expectedVersion
+		return err
+	}
+	return nil
+}
+
+func (w versionedWrites) Delete(ctx context.Context, university *models.University) error {
+	deletedAt := time.Now()
+	err := w.update(ctx, university.ID, university.Version, true, models.RevisionDeleted, func(current models.University) (models.University, error) {
+		current.DeletedAt = &deletedAt
+		current.UpdatedAt = deletedAt
+		current.Version = university.Version + 1
+		return current, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	university.DeletedAt = &deletedAt
+	university.UpdatedAt = deletedAt
+	university.Version++
+	return nil
+}
+
+func (w versionedWrites) Restore(ctx context.Context, university *models.University) error {
+	updatedAt := time.Now()
+	err := w.update(ctx, university.ID, university.Version, true, models.RevisionRestored, func(current models.University) (models.University, error) {
+		current.DeletedAt = nil
+		current.UpdatedAt = updatedAt
+		current.Version = university.Version + 1
+		return current, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	university.DeletedAt = nil
+	university.UpdatedAt = updatedAt
+	university.Version++
+	return nil
+}
+
+func (w versionedWrites) Revert(ctx context.Context, university *models.University) error {
+	expectedVersion := university.Version
+	university.UpdatedAt = time.Now()
+	university.NameNormalized = textutil.Fold(university.Name)
+	university.Version = expectedVersion + 1
+
+	err := w.update(ctx, university.ID, expectedVersion, true, models.RevisionReverted, func(current models.University) (models.University, error) {
+		return *university, nil
+	})
+	if err != nil {
+		university.Version = expectedVersion
+		return err
+	}
+	return nil
+}
+
+// setFields copia de university para current os campos informados,
+// identificados pelo nome da tag bson como no $set do UniversityRepository,
+// além de updated_at e version.
+func setFields(current models.University, university *models.University, fields []string) (models.University, error) {
+	source, err := toDocument(university)
+	if err != nil {
+		return current, err
+	}
+	target, err := toDocument(&current)
+	if err != nil {
+		return current, err
+	}
+
+	target["updated_at"] = source["updated_at"]
+	target["version"] = source["version"]
+	for _, field := range fields {
+		value, ok := source[field]
+		if !ok {
+			return current, fmt.Errorf("unknown field: %s", field)
+		}
+		target[field] = value
+		if field == "name" {
+			target["name_normalized"] = source["name_normalized"]
+		}
+	}
+
+	data, err := bson.Marshal(target)
+	if err != nil {
+		return current, err
+	}
+	var updated models.University
+	return updated, bson.Unmarshal(data, &updated)
+}
+
+func toDocument(university *models.University) (bson.M, error) {
+	data, err := bson.Marshal(university)
+	if err != nil {
+		return nil, err
+	}
+	var document bson.M
+	return document, bson.Unmarshal(data, &document)
+}
@@ -0,0 +1,221 @@
+package schema
+
+import (
+	"context"
+	_ "embed"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/linkedin/goavro/v2"
+	"github.com/university-service/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UniversityEventSchema é o schema Avro atual de models.UniversityEvent. Ao
+// trocar de arquivo, atualize models.EventSchemaVersion para a mesma versão.
+//
+//go:embed avro/university_event.v2.avsc
+var UniversityEventSchema string
+
+// As mensagens seguem o formato do Confluent Schema Registry: um byte 0,
+// o ID do schema em 4 bytes big-endian e os dados codificados em Avro.
+const (
+	magicByte  = 0
+	headerSize = 5
+)
+
+const universityRecord = "com.university.events.University"
+
+var ErrInvalidMessage = errors.New("invalid avro message")
+
+// AvroCodec codifica eventos com o schema registrado e decodifica mensagens
+// de qualquer versão de schema conhecida pelo registro.
+type AvroCodec struct {
+	registry Registry
+	id       int
+	codec    *goavro.Codec
+
+	mu   sync.Mutex
+	byID map[int]*goavro.Codec
+}
+
+// NewAvroCodec registra UniversityEventSchema no subject informado.
+func NewAvroCodec(ctx context.Context, registry Registry, subject string) (*AvroCodec, error) {
+	codec, err := goavro.NewCodec(UniversityEventSchema)
+	if err != nil {
+		return nil, err
+	}
+	id, err := registry.Register(ctx, subject, UniversityEventSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AvroCodec{
+		registry: registry,
+		id:       id,
+		codec:    codec,
+		byID:     map[int]*goavro.Codec{id: codec},
+	}, nil
+}
+
+// SchemaID retorna o ID do schema usado na codificação.
+func (c *AvroCodec) SchemaID() int {
+	return c.id
+}
+
+func (c *AvroCodec) Encode(event *models.UniversityEvent) ([]byte, error) {
+	message := make([]byte, headerSize, 256)
+	message[0] = magicByte
+	binary.BigEndian.PutUint32(message[1:headerSize], uint32(c.id))
+	return c.codec.BinaryFromNative(message, eventToNative(event))
+}
+
+func (c *AvroCodec) Decode(ctx context.Context, message []byte) (*models.UniversityEvent, error) {
+	if len(message) < headerSize || message[0] != magicByte {
+		return nil, ErrInvalidMessage
+	}
+	codec, err := c.codecFor(ctx, int(binary.BigEndian.Uint32(message[1:headerSize])))
+	if err != nil {
+		return nil, err
+	}
+
+	native, _, err := codec.NativeFromBinary(message[headerSize:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+	}
+	record, ok := native.(map[string]interface{})
+	if !ok {
+		return nil, ErrInvalidMessage
+	}
+	return eventFromNative(record), nil
+}
+
+func (c *AvroCodec) codecFor(ctx context.Context, id int) (*goavro.Codec, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if codec, ok := c.byID[id]; ok {
+		return codec, nil
+	}
+	schema, err := c.registry.Schema(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	codec, err := goavro.NewCodec(schema)
+	if err != nil {
+		return nil, err
+	}
+	c.byID[id] = codec
+	return codec, nil
+}
+
+func eventToNative(event *models.UniversityEvent) map[string]interface{} {
+	changedFields := make([]interface{}, len(event.ChangedFields))
+	for i, field := range event.ChangedFields {
+		changedFields[i] = field
+	}
+
+	var correlationID interface{}
+	if event.CorrelationID != "" {
+		correlationID = goavro.Union("string", event.CorrelationID)
+	}
+
+	return map[string]interface{}{
+		"id":             event.ID,
+		"type":           event.Type,
+		"schema_version": int32(event.SchemaVersion),
+		"occurred_at":    event.OccurredAt,
+		"correlation_id": correlationID,
+		"university":     universityToNative(event.University),
+		"changed_fields": changedFields,
+		"before":         universityToNative(event.Before),
+		"after":          universityToNative(event.After),
+	}
+}
+
+func universityToNative(university *models.University) interface{} {
+	if university == nil {
+		return nil
+	}
+
+	var deletedAt interface{}
+	if university.DeletedAt != nil {
+		deletedAt = goavro.Union("long.timestamp-millis", *university.DeletedAt)
+	}
+
+	return goavro.Union(universityRecord, map[string]interface{}{
+		"id":         university.ID.Hex(),
+		"name":       university.Name,
+		"address":    university.Address,
+		"phone":      university.Phone,
+		"email":      university.Email,
+		"website":    university.Website,
+		"created_at": university.CreatedAt,
+		"updated_at": university.UpdatedAt,
+		"version":    university.Version,
+		"deleted_at": deletedAt,
+	})
+}
+
+func eventFromNative(record map[string]interface{}) *models.UniversityEvent {
+	event := &models.UniversityEvent{
+		University: universityFromNative(record["university"]),
+		Before:     universityFromNative(record["before"]),
+		After:      universityFromNative(record["after"]),
+	}
+	event.ID, _ = record["id"].(string)
+	event.Type, _ = record["type"].(string)
+	if version, ok := record["schema_version"].(int32); ok {
+		event.SchemaVersion = int(version)
+	}
+	event.OccurredAt, _ = record["occurred_at"].(time.Time)
+	event.CorrelationID, _ = unionValue(record["correlation_id"]).(string)
+	if fields, ok := record["changed_fields"].([]interface{}); ok {
+		for _, field := range fields {
+			if name, ok := field.(string); ok {
+				event.ChangedFields = append(event.ChangedFields, name)
+			}
+		}
+	}
+	return event
+}
+
+func universityFromNative(value interface{}) *models.University {
+	record, ok := unionValue(value).(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	university := &models.University{}
+	if id, ok := record["id"].(string); ok {
+		university.ID, _ = primitive.ObjectIDFromHex(id)
+	}
+	university.Name, _ = record["name"].(string)
+	university.Address, _ = record["address"].(string)
+	university.Phone, _ = record["phone"].(string)
+	university.Email, _ = record["email"].(string)
+	university.Website, _ = record["website"].(string)
+	university.CreatedAt, _ = record["created_at"].(time.Time)
+	university.UpdatedAt, _ = record["updated_at"].(time.Time)
+	university.Version, _ = record["version"].(int64)
+	if deletedAt, ok := unionValue(record["deleted_at"]).(time.Time); ok {
+		university.DeletedAt = &deletedAt
+	}
+	return university
+}
+
+// unionValue extrai o valor de uma union decodificada pelo goavro, que vem
+// como nil ou como um mapa de um único elemento {"tipo": valor}.
+func unionValue(value interface{}) interface{} {
+	union, ok := value.(map[string]interface{})
+	if !ok || len(union) != 1 {
+		return nil
+	}
+	for _, v := range union {
+		return v
+	}
+	return nil
+}
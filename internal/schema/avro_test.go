@@ -0,0 +1,74 @@
+package schema
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/university-service/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestAvroCodec_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	registry, err := NewFileRegistry(filepath.Join(t.TempDir(), "registry.json"))
+	assert.NoError(t, err)
+
+	codec, err := NewAvroCodec(ctx, registry, "university_events-value")
+	assert.NoError(t, err)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	before := &models.University{
+		ID:        primitive.NewObjectID(),
+		Name:      "Test University",
+		Address:   "123 Test St",
+		Phone:     "(11) 1234-5678",
+		Email:     "test@university.edu",
+		CreatedAt: now,
+		UpdatedAt: now,
+		Version:   1,
+	}
+	after := *before
+	after.Name = "Updated University"
+	after.Version = 2
+	after.DeletedAt = &now
+
+	event := models.NewUniversityEvent(models.EventUniversityUpdated, &after)
+	event.OccurredAt = now
+	event.CorrelationID = "req-123"
+	event.SetChanges(before, &after)
+
+	message, err := codec.Encode(event)
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0), message[0])
+
+	decoded, err := codec.Decode(ctx, message)
+	assert.NoError(t, err)
+	assert.Equal(t, event.ID, decoded.ID)
+	assert.Equal(t, event.Type, decoded.Type)
+	assert.Equal(t, event.SchemaVersion, decoded.SchemaVersion)
+	assert.True(t, now.Equal(decoded.OccurredAt))
+	assert.Equal(t, "req-123", decoded.CorrelationID)
+	assert.Equal(t, []string{"name"}, decoded.ChangedFields)
+	assert.Equal(t, after.ID, decoded.University.ID)
+	assert.Equal(t, "Updated University", decoded.After.Name)
+	assert.Equal(t, "Test University", decoded.Before.Name)
+	assert.Nil(t, decoded.Before.DeletedAt)
+	assert.True(t, now.Equal(*decoded.After.DeletedAt))
+
+	t.Run("Snapshot", func(t *testing.T) {
+		message, err := codec.Encode(models.NewUniversityEvent(models.EventUniversitySnapshot, before))
+		assert.NoError(t, err)
+
+		decoded, err := codec.Decode(ctx, message)
+		assert.NoError(t, err)
+		assert.Equal(t, models.EventUniversitySnapshot, decoded.Type)
+	})
+
+	t.Run("Invalid Message", func(t *testing.T) {
+		_, err := codec.Decode(ctx, []byte(`{"id":"x"}`))
+		assert.ErrorIs(t, err, ErrInvalidMessage)
+	})
+}
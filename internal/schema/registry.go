@@ -0,0 +1,135 @@
+package schema
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+var ErrSchemaNotFound = errors.New("schema not found")
+
+// Registry guarda os schemas usados nas mensagens, no mesmo modelo do
+// Confluent Schema Registry: cada schema recebe um ID global e uma versão
+// dentro do subject em que foi registrado.
+type Registry interface {
+	// Register registra o schema no subject e retorna seu ID. Registrar de novo
+	// um schema já conhecido retorna o ID existente.
+	Register(ctx context.Context, subject, schema string) (int, error)
+	Schema(ctx context.Context, id int) (string, error)
+}
+
+type registryEntry struct {
+	ID      int    `json:"id"`
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+	Schema  string `json:"schema"`
+}
+
+// FileRegistry é uma implementação de Registry em um arquivo JSON local,
+// para desenvolvimento e testes.
+type FileRegistry struct {
+	path    string
+	mu      sync.Mutex
+	entries []registryEntry
+}
+
+// NewFileRegistry abre o registro gravado em path, que é criado no primeiro
+// Register caso ainda não exista.
+func NewFileRegistry(path string) (*FileRegistry, error) {
+	registry := &FileRegistry{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return registry, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &registry.entries); err != nil {
+		return nil, fmt.Errorf("invalid schema registry file %s: %w", path, err)
+	}
+	return registry, nil
+}
+
+func (r *FileRegistry) Register(ctx context.Context, subject, schema string) (int, error) {
+	normalized, err := normalize(schema)
+	if err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Um schema já registrado em outro subject mantém o mesmo ID
+	id, maxID, version := 0, 0, 0
+	for _, entry := range r.entries {
+		if entry.Schema == normalized {
+			if entry.Subject == subject {
+				return entry.ID, nil
+			}
+			id = entry.ID
+		}
+		if entry.ID > maxID {
+			maxID = entry.ID
+		}
+		if entry.Subject == subject && entry.Version > version {
+			version = entry.Version
+		}
+	}
+	if id == 0 {
+		id = maxID + 1
+	}
+
+	r.entries = append(r.entries, registryEntry{ID: id, Subject: subject, Version: version + 1, Schema: normalized})
+	if err := r.save(); err != nil {
+		r.entries = r.entries[:len(r.entries)-1]
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *FileRegistry) Schema(ctx context.Context, id int) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, entry := range r.entries {
+		if entry.ID == id {
+			return entry.Schema, nil
+		}
+	}
+	return "", fmt.Errorf("%w: id %d", ErrSchemaNotFound, id)
+}
+
+func (r *FileRegistry) save() error {
+	data, err := json.MarshalIndent(r.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(r.path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+
+	// Grava em um arquivo temporário e renomeia para não deixar o registro pela metade
+	tmp := r.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, r.path)
+}
+
+// normalize remove espaços do JSON para que o mesmo schema com formatação
+// diferente receba o mesmo ID.
+func normalize(schema string) (string, error) {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, []byte(schema)); err != nil {
+		return "", fmt.Errorf("invalid schema: %w", err)
+	}
+	return buf.String(), nil
+}
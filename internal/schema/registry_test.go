@@ -0,0 +1,49 @@
+package schema
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileRegistry(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "registry.json")
+
+	registry, err := NewFileRegistry(path)
+	assert.NoError(t, err)
+
+	id, err := registry.Register(ctx, "events-value", `{"type": "string"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, id)
+
+	t.Run("Same Schema", func(t *testing.T) {
+		again, err := registry.Register(ctx, "events-value", `{"type":"string"}`)
+		assert.NoError(t, err)
+		assert.Equal(t, id, again)
+
+		other, err := registry.Register(ctx, "other-value", `{"type":"string"}`)
+		assert.NoError(t, err)
+		assert.Equal(t, id, other)
+	})
+
+	t.Run("New Version", func(t *testing.T) {
+		next, err := registry.Register(ctx, "events-value", `{"type":"long"}`)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, next)
+	})
+
+	t.Run("Reload", func(t *testing.T) {
+		reloaded, err := NewFileRegistry(path)
+		assert.NoError(t, err)
+
+		schema, err := reloaded.Schema(ctx, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"type":"long"}`, schema)
+
+		_, err = reloaded.Schema(ctx, 3)
+		assert.ErrorIs(t, err, ErrSchemaNotFound)
+	})
+}
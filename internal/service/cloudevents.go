@@ -0,0 +1,183 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/university-service/internal/models"
+)
+
+// EventFormat define como os eventos são codificados nas mensagens do Kafka.
+type EventFormat string
+
+const (
+	// FormatLegacy é o JSON próprio do serviço ({"type", "university", ...})
+	FormatLegacy EventFormat = "legacy"
+	// FormatCloudEventsStructured envia o CloudEvent completo no corpo da mensagem
+	FormatCloudEventsStructured EventFormat = "cloudevents-structured"
+	// FormatCloudEventsBinary envia os atributos do CloudEvent como cabeçalhos
+	// ce_* e apenas os dados no corpo da mensagem
+	FormatCloudEventsBinary EventFormat = "cloudevents-binary"
+	// FormatAvro codifica o evento em Avro com o ID do schema no início da
+	// mensagem; exige WithAvroCodec
+	FormatAvro EventFormat = "avro"
+)
+
+const (
+	DefaultCloudEventsSource = "/university-service"
+
+	cloudEventsSpecVersion     = "1.0"
+	cloudEventsTypePrefix      = "com.university."
+	cloudEventsContentType     = "application/cloudevents+json"
+	cloudEventsDataContentType = "application/json"
+)
+
+func ParseEventFormat(value string) (EventFormat, error) {
+	switch format := EventFormat(value); format {
+	case "":
+		return FormatLegacy, nil
+	case FormatLegacy, FormatCloudEventsStructured, FormatCloudEventsBinary, FormatAvro:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown event format: %s", value)
+	}
+}
+
+// cloudEventData é o conteúdo do atributo data dos CloudEvents.
+type cloudEventData struct {
+	University    *models.University `json:"university"`
+	ChangedFields []string           `json:"changed_fields,omitempty"`
+	Before        *models.University `json:"before,omitempty"`
+	After         *models.University `json:"after,omitempty"`
+}
+
+type cloudEvent struct {
+	SpecVersion     string         `json:"specversion"`
+	Type            string         `json:"type"`
+	Source          string         `json:"source"`
+	ID              string         `json:"id"`
+	Time            time.Time      `json:"time"`
+	Subject         string         `json:"subject,omitempty"`
+	DataContentType string         `json:"datacontenttype"`
+	CorrelationID   string         `json:"correlationid,omitempty"`
+	SchemaVersion   int            `json:"schemaversion"`
+	Data            cloudEventData `json:"data"`
+}
+
+// CloudEventType converte o tipo interno do evento para o tipo do CloudEvent,
+// por exemplo "university_created" para "com.university.created".
+func CloudEventType(eventType string) string {
+	return cloudEventsTypePrefix + strings.TrimPrefix(eventType, "university_")
+}
+
+func newCloudEvent(event *models.UniversityEvent, source string) cloudEvent {
+	return cloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		Type:            CloudEventType(event.Type),
+		Source:          source,
+		ID:              event.ID,
+		Time:            event.OccurredAt,
+		Subject:         event.Key(),
+		DataContentType: cloudEventsDataContentType,
+		CorrelationID:   event.CorrelationID,
+		SchemaVersion:   event.SchemaVersion,
+		Data: cloudEventData{
+			University:    event.University,
+			ChangedFields: event.ChangedFields,
+			Before:        event.Before,
+			After:         event.After,
+		},
+	}
+}
+
+func encodeStructuredCloudEvent(event *models.UniversityEvent, source string) ([]byte, []kafka.Header, error) {
+	value, err := json.Marshal(newCloudEvent(event, source))
+	if err != nil {
+		return nil, nil, err
+	}
+	return value, []kafka.Header{{Key: "content-type", Value: []byte(cloudEventsContentType)}}, nil
+}
+
+// encodeBinaryCloudEvent segue o binding Kafka do CloudEvents: atributos em
+// cabeçalhos com prefixo ce_ e content-type com o tipo dos dados.
+func encodeBinaryCloudEvent(event *models.UniversityEvent, source string) ([]byte, []kafka.Header, error) {
+	ce := newCloudEvent(event, source)
+	value, err := json.Marshal(ce.Data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	headers := []kafka.Header{
+		{Key: "ce_specversion", Value: []byte(ce.SpecVersion)},
+		{Key: "ce_type", Value: []byte(ce.Type)},
+		{Key: "ce_source", Value: []byte(ce.Source)},
+		{Key: "ce_id", Value: []byte(ce.ID)},
+		{Key: "ce_time", Value: []byte(ce.Time.Format(time.RFC3339Nano))},
+		{Key: "ce_schemaversion", Value: []byte(fmt.Sprint(ce.SchemaVersion))},
+		{Key: "content-type", Value: []byte(ce.DataContentType)},
+	}
+	if ce.Subject != "" {
+		headers = append(headers, kafka.Header{Key: "ce_subject", Value: []byte(ce.Subject)})
+	}
+	if ce.CorrelationID != "" {
+		headers = append(headers, kafka.Header{Key: "ce_correlationid", Value: []byte(ce.CorrelationID)})
+	}
+	return value, headers, nil
+}
+
+func eventFromCloudEvent(ce cloudEvent) *models.UniversityEvent {
+	return &models.UniversityEvent{
+		ID:            ce.ID,
+		Type:          "university_" + strings.TrimPrefix(ce.Type, cloudEventsTypePrefix),
+		SchemaVersion: ce.SchemaVersion,
+		OccurredAt:    ce.Time,
+		CorrelationID: ce.CorrelationID,
+		University:    ce.Data.University,
+		ChangedFields: ce.Data.ChangedFields,
+		Before:        ce.Data.Before,
+		After:         ce.Data.After,
+	}
+}
+
+func decodeStructuredCloudEvent(value []byte) (*models.UniversityEvent, error) {
+	var ce cloudEvent
+	if err := json.Unmarshal(value, &ce); err != nil {
+		return nil, err
+	}
+	if ce.SpecVersion != cloudEventsSpecVersion {
+		return nil, fmt.Errorf("unsupported cloudevents specversion: %s", ce.SpecVersion)
+	}
+	return eventFromCloudEvent(ce), nil
+}
+
+func decodeBinaryCloudEvent(headers map[string]string, value []byte) (*models.UniversityEvent, error) {
+	ce := cloudEvent{
+		SpecVersion:   headers["ce_specversion"],
+		Type:          headers["ce_type"],
+		Source:        headers["ce_source"],
+		ID:            headers["ce_id"],
+		Subject:       headers["ce_subject"],
+		CorrelationID: headers["ce_correlationid"],
+	}
+	if ce.SpecVersion != cloudEventsSpecVersion {
+		return nil, fmt.Errorf("unsupported cloudevents specversion: %s", ce.SpecVersion)
+	}
+
+	var err error
+	if ce.Time, err = time.Parse(time.RFC3339Nano, headers["ce_time"]); err != nil {
+		return nil, err
+	}
+	if version := headers["ce_schemaversion"]; version != "" {
+		if ce.SchemaVersion, err = strconv.Atoi(version); err != nil {
+			return nil, err
+		}
+	}
+	if err := json.Unmarshal(value, &ce.Data); err != nil {
+		return nil, err
+	}
+	return eventFromCloudEvent(ce), nil
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/university-service/internal/models"
+	"github.com/university-service/internal/schema"
+)
+
+// Projection recebe os eventos lidos do tópico. Apply deve ser idempotente e
+// retornar false quando o evento já tiver sido aplicado.
+type Projection interface {
+	Apply(ctx context.Context, event *models.UniversityEvent) (bool, error)
+}
+
+// EventDecoder interpreta as mensagens em qualquer um dos formatos de
+// EventFormat, identificado pelos cabeçalhos da mensagem.
+type EventDecoder struct {
+	avro *schema.AvroCodec
+}
+
+// NewEventDecoder cria o decodificador; avro pode ser nil se não houver
+// mensagens nesse formato no tópico.
+func NewEventDecoder(avro *schema.AvroCodec) *EventDecoder {
+	return &EventDecoder{avro: avro}
+}
+
+func (d *EventDecoder) Decode(ctx context.Context, msg kafka.Message) (*models.UniversityEvent, error) {
+	headers := make(map[string]string, len(msg.Headers))
+	for _, h := range msg.Headers {
+		headers[h.Key] = string(h.Value)
+	}
+
+	switch {
+	case headers["content-type"] == avroContentType:
+		if d.avro == nil {
+			return nil, errors.New("avro message without a schema registry")
+		}
+		return d.avro.Decode(ctx, msg.Value)
+	case headers["content-type"] == cloudEventsContentType:
+		return decodeStructuredCloudEvent(msg.Value)
+	case headers["ce_specversion"] != "":
+		return decodeBinaryCloudEvent(headers, msg.Value)
+	default:
+		var event models.UniversityEvent
+		if err := json.Unmarshal(msg.Value, &event); err != nil {
+			return nil, err
+		}
+		if event.ID == "" {
+			// Mensagens publicadas antes do ID do evento existir
+			event.ID = headers[HeaderEventID]
+		}
+		return &event, nil
+	}
+}
+
+// ProjectionConsumer lê o tópico de eventos em um consumer group e aplica cada
+// evento à projeção. O offset só é confirmado depois que o evento foi
+// aplicado, então uma mensagem pode ser reentregue após uma falha; a projeção
+// descarta as duplicatas pelo ID do evento.
+type ProjectionConsumer struct {
+	reader     *kafka.Reader
+	decoder    *EventDecoder
+	projection Projection
+	retry      time.Duration
+}
+
+func NewProjectionConsumer(brokers []string, topic, groupID string, security KafkaSecurity, decoder *EventDecoder, projection Projection) *ProjectionConsumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: brokers,
+		Topic:   topic,
+		GroupID: groupID,
+		Dialer:  security.dialer(),
+	})
+
+	return &ProjectionConsumer{
+		reader:     reader,
+		decoder:    decoder,
+		projection: projection,
+		retry:      time.Second,
+	}
+}
+
+// Run consome mensagens até o contexto ser cancelado.
+func (c *ProjectionConsumer) Run(ctx context.Context) error {
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		// Erros ao aplicar o evento são tentados de novo; não há como avançar
+		// sem perder o evento na projeção
+		for {
+			err = c.Handle(ctx, msg)
+			if err == nil || ctx.Err() != nil {
+				break
+			}
+			log.Printf("projection consumer: partition %d offset %d: %v", msg.Partition, msg.Offset, err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(c.retry):
+			}
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if err := c.reader.CommitMessages(ctx, msg); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// Handle decodifica e aplica uma mensagem. Mensagens que não podem ser
+// decodificadas são registradas no log e ignoradas.
+func (c *ProjectionConsumer) Handle(ctx context.Context, msg kafka.Message) error {
+	event, err := c.decoder.Decode(ctx, msg)
+	if err != nil {
+		log.Printf("projection consumer: skipping partition %d offset %d: %v", msg.Partition, msg.Offset, err)
+		return nil
+	}
+	if event.University == nil {
+		log.Printf("projection consumer: skipping event %s without university", event.ID)
+		return nil
+	}
+
+	applied, err := c.projection.Apply(ctx, event)
+	if err != nil {
+		return err
+	}
+	if !applied {
+		log.Printf("projection consumer: duplicate or outdated event %s ignored", event.ID)
+	}
+	return nil
+}
+
+func (c *ProjectionConsumer) Close() error {
+	return c.reader.Close()
+}
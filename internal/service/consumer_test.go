@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/university-service/internal/models"
+	"github.com/university-service/internal/schema"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryProjection guarda os eventos aplicados, descartando IDs repetidos
+type memoryProjection struct {
+	applied map[string]*models.UniversityEvent
+}
+
+func (p *memoryProjection) Apply(ctx context.Context, event *models.UniversityEvent) (bool, error) {
+	if _, ok := p.applied[event.ID]; ok {
+		return false, nil
+	}
+	p.applied[event.ID] = event
+	return true, nil
+}
+
+func TestEventDecoder_Decode(t *testing.T) {
+	ctx := context.Background()
+	registry, err := schema.NewFileRegistry(filepath.Join(t.TempDir(), "registry.json"))
+	assert.NoError(t, err)
+	codec, err := schema.NewAvroCodec(ctx, registry, "test_topic-value")
+	assert.NoError(t, err)
+	decoder := NewEventDecoder(codec)
+
+	uni := &models.University{ID: primitive.NewObjectID(), Name: "Test University", CreatedAt: time.Now()}
+	before := *uni
+	before.Name = "Old Name"
+
+	for _, format := range []EventFormat{FormatLegacy, FormatCloudEventsStructured, FormatCloudEventsBinary, FormatAvro} {
+		t.Run(string(format), func(t *testing.T) {
+			service := NewKafkaService([]string{"localhost:9092"}, "test_topic", WithEventFormat(format), WithAvroCodec(codec))
+			defer service.Close()
+
+			event := models.NewUniversityEvent(models.EventUniversityUpdated, uni)
+			event.SetChanges(&before, uni)
+			msg, err := service.buildMessage(WithCorrelationID(ctx, "req-123"), event)
+			assert.NoError(t, err)
+
+			decoded, err := decoder.Decode(ctx, msg)
+			assert.NoError(t, err)
+			assert.Equal(t, event.ID, decoded.ID)
+			assert.Equal(t, models.EventUniversityUpdated, decoded.Type)
+			assert.Equal(t, models.EventSchemaVersion, decoded.SchemaVersion)
+			assert.True(t, event.OccurredAt.Sub(decoded.OccurredAt) < time.Millisecond)
+			assert.Equal(t, "req-123", decoded.CorrelationID)
+			assert.Equal(t, uni.ID, decoded.University.ID)
+			assert.Equal(t, "Old Name", decoded.Before.Name)
+			assert.Equal(t, []string{"name"}, decoded.ChangedFields)
+		})
+	}
+}
+
+func TestProjectionConsumer_Handle(t *testing.T) {
+	ctx := context.Background()
+	projection := &memoryProjection{applied: map[string]*models.UniversityEvent{}}
+	consumer := &ProjectionConsumer{decoder: NewEventDecoder(nil), projection: projection}
+
+	service := NewKafkaService([]string{"localhost:9092"}, "test_topic")
+	defer service.Close()
+
+	uni := &models.University{ID: primitive.NewObjectID(), Name: "Test University"}
+	msg, err := service.buildMessage(ctx, models.NewUniversityEvent(models.EventUniversityCreated, uni))
+	assert.NoError(t, err)
+
+	// A mesma mensagem entregue duas vezes é aplicada uma única vez
+	assert.NoError(t, consumer.Handle(ctx, msg))
+	assert.NoError(t, consumer.Handle(ctx, msg))
+	assert.Len(t, projection.applied, 1)
+
+	// Mensagens inválidas são ignoradas
+	msg.Value = []byte("not json")
+	assert.NoError(t, consumer.Handle(ctx, msg))
+	assert.Len(t, projection.applied, 1)
+}
@@ -0,0 +1,16 @@
+package service
+
+import "context"
+
+type correlationIDKey struct{}
+
+// WithCorrelationID associa ao contexto o identificador da requisição que deu
+// origem aos eventos, enviado no cabeçalho correlation_id das mensagens.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
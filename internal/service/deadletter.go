@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/university-service/internal/models"
+)
+
+// Cabeçalhos acrescentados às mensagens do tópico de dead letters
+const (
+	HeaderDeadLetterError    = "dlq_error"
+	HeaderDeadLetterAttempts = "dlq_attempts"
+	HeaderDeadLetterFailedAt = "dlq_failed_at"
+	HeaderDeadLetterTopic    = "dlq_original_topic"
+)
+
+// Tempo de espera por mensagens ao ler o tópico de dead letters. A primeira
+// leitura inclui a entrada no consumer group, que é mais demorada.
+const (
+	deadLetterJoinTimeout = 10 * time.Second
+	deadLetterIdleTimeout = 2 * time.Second
+)
+
+var ErrDeadLettersDisabled = errors.New("dead letters are not configured")
+
+// deadLetter desvia um evento que esgotou as tentativas para o tópico de dead
+// letters ou, se ele também estiver inacessível, para o arquivo de spool. Só
+// retorna erro se o evento não puder ser guardado em nenhum dos dois.
+func (s *KafkaService) deadLetter(ctx context.Context, event *models.UniversityEvent, message kafka.Message, attempts int, cause error) error {
+	letter := models.DeadLetter{
+		Event:    event,
+		Error:    cause.Error(),
+		Attempts: attempts,
+		FailedAt: time.Now().UTC(),
+	}
+
+	if s.deadLetters != nil {
+		// O writer do tópico de dead letters já define o tópico
+		message.Topic = ""
+		message.Headers = append(append([]kafka.Header{}, message.Headers...),
+			kafka.Header{Key: HeaderDeadLetterError, Value: []byte(letter.Error)},
+			kafka.Header{Key: HeaderDeadLetterAttempts, Value: []byte(strconv.Itoa(attempts))},
+			kafka.Header{Key: HeaderDeadLetterFailedAt, Value: []byte(letter.FailedAt.Format(time.RFC3339Nano))},
+			kafka.Header{Key: HeaderDeadLetterTopic, Value: []byte(s.writer.Topic)},
+		)
+		err := s.deadLetters.WriteMessages(ctx, message)
+		if err == nil {
+			log.Printf("kafka: event %s sent to dead letter topic %s: %v", event.ID, s.deadLetterTopic, cause)
+			return nil
+		}
+		log.Printf("kafka: failed to send event %s to dead letter topic: %v", event.ID, err)
+	}
+
+	if s.spool != nil {
+		err := s.spool.append(letter)
+		if err == nil {
+			log.Printf("kafka: event %s written to spool file %s: %v", event.ID, s.spool.path, cause)
+			return nil
+		}
+		log.Printf("kafka: failed to write event %s to spool file: %v", event.ID, err)
+	}
+
+	return cause
+}
+
+// ListDeadLetters retorna até limit dead letters ainda não reenviadas:
+// primeiro as do arquivo de spool, depois as do tópico de dead letters.
+func (s *KafkaService) ListDeadLetters(ctx context.Context, limit int) ([]models.DeadLetter, error) {
+	if s.deadLetters == nil && s.spool == nil {
+		return nil, ErrDeadLettersDisabled
+	}
+
+	letters := make([]models.DeadLetter, 0)
+	if s.spool != nil {
+		spooled, err := s.spool.read()
+		if err != nil {
+			return nil, err
+		}
+		letters = append(letters, spooled...)
+		if limit > 0 && len(letters) >= limit {
+			return letters[:limit], nil
+		}
+	}
+
+	if s.deadLetters != nil {
+		decoder := NewEventDecoder(s.avro)
+		err := s.readDeadLetters(ctx, limit-len(letters), false, func(msg kafka.Message) error {
+			letter, err := deadLetterFromMessage(ctx, decoder, msg)
+			if err != nil {
+				return err
+			}
+			letters = append(letters, letter)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return letters, nil
+}
+
+// ReplayDeadLetters publica de novo no tópico de eventos até limit dead
+// letters, na mesma ordem de ListDeadLetters, e retorna quantas foram
+// reenviadas. Para na primeira falha; as restantes continuam pendentes.
+func (s *KafkaService) ReplayDeadLetters(ctx context.Context, limit int) (int, error) {
+	if s.deadLetters == nil && s.spool == nil {
+		return 0, ErrDeadLettersDisabled
+	}
+
+	replayed := 0
+	if s.spool != nil {
+		n, err := s.spool.drain(limit, func(letter models.DeadLetter) error {
+			message, err := s.buildMessage(ctx, letter.Event)
+			if err != nil {
+				return err
+			}
+			_, err = s.write(ctx, message)
+			return err
+		})
+		replayed += n
+		if err != nil {
+			return replayed, err
+		}
+		if limit > 0 && replayed >= limit {
+			return replayed, nil
+		}
+	}
+
+	if s.deadLetters != nil {
+		err := s.readDeadLetters(ctx, limit-replayed, true, func(msg kafka.Message) error {
+			message := kafka.Message{Key: msg.Key, Value: msg.Value, Time: msg.Time}
+			for _, h := range msg.Headers {
+				if !strings.HasPrefix(h.Key, "dlq_") {
+					message.Headers = append(message.Headers, h)
+				}
+			}
+			if _, err := s.write(ctx, message); err != nil {
+				return err
+			}
+			replayed++
+			return nil
+		})
+		if err != nil {
+			return replayed, err
+		}
+	}
+	return replayed, nil
+}
+
+// readDeadLetters lê o tópico de dead letters a partir do último offset
+// confirmado do consumer group de reenvio, até limit mensagens (0 para todas)
+// ou até não haver mais mensagens. Com commit, o offset de cada mensagem
+// processada por fn é confirmado, e ela não volta a ser lida.
+func (s *KafkaService) readDeadLetters(ctx context.Context, limit int, commit bool, fn func(kafka.Message) error) error {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: s.brokers,
+		Topic:   s.deadLetterTopic,
+		GroupID: s.deadLetterTopic + "-replay",
+		MaxWait: 500 * time.Millisecond,
+		Dialer:  s.security.dialer(),
+	})
+	defer reader.Close()
+
+	wait := deadLetterJoinTimeout
+	for n := 0; limit <= 0 || n < limit; n++ {
+		fetchCtx, cancel := context.WithTimeout(ctx, wait)
+		msg, err := reader.FetchMessage(fetchCtx)
+		cancel()
+		if err != nil {
+			if ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+				return nil
+			}
+			return err
+		}
+		wait = deadLetterIdleTimeout
+
+		if err := fn(msg); err != nil {
+			return err
+		}
+		if commit {
+			if err := reader.CommitMessages(ctx, msg); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func deadLetterFromMessage(ctx context.Context, decoder *EventDecoder, msg kafka.Message) (models.DeadLetter, error) {
+	event, err := decoder.Decode(ctx, msg)
+	if err != nil {
+		return models.DeadLetter{}, err
+	}
+
+	letter := models.DeadLetter{
+		Event:     event,
+		Source:    models.DeadLetterTopic,
+		Partition: msg.Partition,
+		Offset:    msg.Offset,
+	}
+	for _, h := range msg.Headers {
+		switch h.Key {
+		case HeaderDeadLetterError:
+			letter.Error = string(h.Value)
+		case HeaderDeadLetterAttempts:
+			letter.Attempts, _ = strconv.Atoi(string(h.Value))
+		case HeaderDeadLetterFailedAt:
+			letter.FailedAt, _ = time.Parse(time.RFC3339Nano, string(h.Value))
+		}
+	}
+	return letter, nil
+}
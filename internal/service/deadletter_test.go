@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/university-service/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, Backoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	assert.Equal(t, 100*time.Millisecond, policy.delay(1))
+	assert.Equal(t, 400*time.Millisecond, policy.delay(3))
+	assert.Equal(t, time.Second, policy.delay(10))
+
+	// Sem MaxBackoff a espera continua dobrando
+	unbounded := RetryPolicy{MaxAttempts: 5, Backoff: 100 * time.Millisecond}
+	assert.Equal(t, 100*time.Millisecond, unbounded.delay(1))
+	assert.Equal(t, 800*time.Millisecond, unbounded.delay(4))
+	assert.Equal(t, 102400*time.Millisecond, unbounded.delay(11))
+	assert.Positive(t, unbounded.delay(100))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		wait := policy.delay(2)
+		assert.True(t, wait >= 100*time.Millisecond && wait <= 300*time.Millisecond, wait)
+	}
+}
+
+func TestSpoolFile(t *testing.T) {
+	spool := &spoolFile{path: filepath.Join(t.TempDir(), "events.spool")}
+
+	letters, err := spool.read()
+	assert.NoError(t, err)
+	assert.Empty(t, letters)
+
+	for _, id := range []string{"1", "2", "3"} {
+		assert.NoError(t, spool.append(models.DeadLetter{Event: &models.UniversityEvent{ID: id}, Error: "broker down"}))
+	}
+
+	letters, err = spool.read()
+	assert.NoError(t, err)
+	assert.Len(t, letters, 3)
+	assert.Equal(t, models.DeadLetterSpool, letters[0].Source)
+
+	var drained []string
+	n, err := spool.drain(2, func(letter models.DeadLetter) error {
+		drained = append(drained, letter.Event.ID)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"1", "2"}, drained)
+
+	letters, err = spool.read()
+	assert.NoError(t, err)
+	assert.Len(t, letters, 1)
+	assert.Equal(t, "3", letters[0].Event.ID)
+}
+
+func TestKafkaService_SpoolWhenKafkaIsDown(t *testing.T) {
+	ctx := context.Background()
+	service := NewKafkaService([]string{"localhost:1"}, "test_topic",
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond}),
+		WithDeadLetterTopic("test_topic.dlq"),
+		WithSpoolFile(filepath.Join(t.TempDir(), "events.spool")),
+	)
+	defer service.Close()
+
+	uni := &models.University{ID: primitive.NewObjectID(), Name: "Test University"}
+	event := models.NewUniversityEvent(models.EventUniversityCreated, uni)
+	assert.NoError(t, service.PublishEvent(ctx, event))
+
+	letters, err := service.spool.read()
+	assert.NoError(t, err)
+	assert.Len(t, letters, 1)
+	assert.Equal(t, event.ID, letters[0].Event.ID)
+	assert.Equal(t, 2, letters[0].Attempts)
+
+	// O reenvio também falha e a dead letter continua no arquivo
+	replayed, err := service.ReplayDeadLetters(ctx, 10)
+	assert.Error(t, err)
+	assert.Equal(t, 0, replayed)
+	letters, err = service.spool.read()
+	assert.NoError(t, err)
+	assert.Len(t, letters, 1)
+}
+
+func TestKafkaService_DirectPublishSkipsSpool(t *testing.T) {
+	ctx := context.Background()
+	service := NewKafkaService([]string{"localhost:1"}, "test_topic",
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond}),
+		WithDeadLetterTopic("test_topic.dlq"),
+		WithSpoolFile(filepath.Join(t.TempDir(), "events.spool")),
+	)
+	defer service.Close()
+
+	uni := &models.University{ID: primitive.NewObjectID(), Name: "Test University"}
+	event := models.NewUniversityEvent(models.EventUniversityCreated, uni)
+	assert.Error(t, service.PublishEventDirect(ctx, event))
+
+	letters, err := service.spool.read()
+	assert.NoError(t, err)
+	assert.Empty(t, letters)
+}
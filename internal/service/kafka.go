@@ -3,45 +3,291 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+	"time"
 
 	"github.com/segmentio/kafka-go"
 	"github.com/university-service/internal/models"
+	"github.com/university-service/internal/schema"
+)
+
+const DefaultProducerName = "university-service"
+
+const avroContentType = "application/vnd.university.event+avro"
+
+// Cabeçalhos das mensagens publicadas
+const (
+	HeaderEventID       = "event_id"
+	HeaderEventType     = "event_type"
+	HeaderSchemaVersion = "schema_version"
+	HeaderTimestamp     = "timestamp"
+	HeaderCorrelationID = "correlation_id"
+	HeaderProducer      = "producer"
 )
 
 type KafkaService struct {
-	writer *kafka.Writer
+	brokers  []string
+	writer   *kafka.Writer
+	producer string
+	format   EventFormat
+	source   string
+	avro     *schema.AvroCodec
+	retry    RetryPolicy
+	security KafkaSecurity
+	settings ProducerSettings
+
+	// Destino dos eventos que esgotaram as tentativas (veja deadletter.go)
+	deadLetterTopic string
+	deadLetters     *kafka.Writer
+	spool           *spoolFile
+}
+
+type KafkaOption func(*KafkaService)
+
+// WithProducerName define o nome enviado no cabeçalho producer.
+func WithProducerName(name string) KafkaOption {
+	return func(s *KafkaService) {
+		if name != "" {
+			s.producer = name
+		}
+	}
+}
+
+// WithEventFormat define a codificação das mensagens. O padrão é FormatLegacy,
+// mantido para os consumidores existentes.
+func WithEventFormat(format EventFormat) KafkaOption {
+	return func(s *KafkaService) {
+		if format != "" {
+			s.format = format
+		}
+	}
+}
+
+// WithCloudEventsSource define o atributo source dos CloudEvents.
+func WithCloudEventsSource(source string) KafkaOption {
+	return func(s *KafkaService) {
+		if source != "" {
+			s.source = source
+		}
+	}
+}
+
+// WithAvroCodec define o codec usado no formato FormatAvro.
+func WithAvroCodec(codec *schema.AvroCodec) KafkaOption {
+	return func(s *KafkaService) {
+		s.avro = codec
+	}
+}
+
+// WithRetryPolicy define as tentativas de envio de cada mensagem.
+func WithRetryPolicy(policy RetryPolicy) KafkaOption {
+	return func(s *KafkaService) {
+		if policy.MaxAttempts > 0 {
+			s.retry = policy
+		}
+	}
+}
+
+// WithDeadLetterTopic define o tópico para onde vão os eventos que esgotaram
+// as tentativas de envio.
+func WithDeadLetterTopic(topic string) KafkaOption {
+	return func(s *KafkaService) {
+		s.deadLetterTopic = topic
+	}
+}
+
+// WithSpoolFile define o arquivo local usado quando nem o tópico de dead
+// letters pode ser acessado.
+func WithSpoolFile(path string) KafkaOption {
+	return func(s *KafkaService) {
+		if path != "" {
+			s.spool = &spoolFile{path: path}
+		}
+	}
+}
+
+// WithSecurity define TLS e SASL para a conexão com os brokers.
+func WithSecurity(security KafkaSecurity) KafkaOption {
+	return func(s *KafkaService) {
+		s.security = security
+	}
+}
+
+// WithProducerSettings ajusta compressão, confirmação, lotes e modo
+// assíncrono do envio. Valores inválidos são ignorados; use
+// ProducerSettings.Validate antes.
+func WithProducerSettings(settings ProducerSettings) KafkaOption {
+	return func(s *KafkaService) {
+		s.settings = settings
+	}
+}
+
+func NewKafkaService(brokers []string, topic string, opts ...KafkaOption) *KafkaService {
+	s := &KafkaService{
+		brokers:  brokers,
+		producer: DefaultProducerName,
+		format:   FormatLegacy,
+		source:   DefaultCloudEventsSource,
+		retry:    DefaultRetryPolicy(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.writer = s.newWriter(topic)
+	if s.settings.Async {
+		s.writer.Async = true
+		s.writer.Completion = s.completion
+	}
+	if s.deadLetterTopic != "" {
+		s.deadLetters = s.newWriter(s.deadLetterTopic)
+	}
+	return s
+}
+
+func (s *KafkaService) newWriter(topic string) *kafka.Writer {
+	compression, _ := parseCompression(s.settings.Compression)
+	acks, _ := parseRequiredAcks(s.settings.RequiredAcks)
+
+	return &kafka.Writer{
+		Addr:      kafka.TCP(s.brokers...),
+		Topic:     topic,
+		Transport: s.security.transport(),
+		// Mesmo particionador do cliente Java, para que mensagens com a mesma
+		// chave caiam na mesma partição independente do produtor
+		Balancer: &kafka.Murmur2Balancer{},
+		// As novas tentativas seguem a RetryPolicy do serviço
+		MaxAttempts:            1,
+		Compression:            compression,
+		RequiredAcks:           acks,
+		BatchSize:              s.settings.BatchSize,
+		BatchTimeout:           s.settings.BatchTimeout,
+		AllowAutoTopicCreation: s.settings.AutoCreateTopic,
+	}
 }
 
-func NewKafkaService(brokers []string, topic string) *KafkaService {
-	writer := kafka.NewWriter(kafka.WriterConfig{
-		Brokers: brokers,
-		Topic:   topic,
-	})
+// completion recebe o resultado dos envios no modo assíncrono. Como o
+// chamador já recebeu a confirmação, as mensagens que falharam vão direto
+// para as dead letters.
+func (s *KafkaService) completion(messages []kafka.Message, err error) {
+	if err == nil {
+		return
+	}
 
-	return &KafkaService{
-		writer: writer,
+	ctx := context.Background()
+	decoder := NewEventDecoder(s.avro)
+	for _, message := range messages {
+		event, decodeErr := decoder.Decode(ctx, message)
+		if decodeErr != nil {
+			log.Printf("kafka: async message lost: %v (%v)", err, decodeErr)
+			continue
+		}
+		if dlqErr := s.deadLetter(ctx, event, message, 1, err); dlqErr != nil {
+			log.Printf("kafka: async event %s lost: %v", event.ID, dlqErr)
+		}
 	}
 }
 
 func (s *KafkaService) PublishUniversityEvent(ctx context.Context, eventType string, university *models.University) error {
-	event := struct {
-		Type       string             `json:"type"`
-		University *models.University `json:"university"`
-	}{
-		Type:       eventType,
-		University: university,
+	return s.PublishEvent(ctx, models.NewUniversityEvent(eventType, university))
+}
+
+func (s *KafkaService) PublishEvent(ctx context.Context, event *models.UniversityEvent) error {
+	message, err := s.buildMessage(ctx, event)
+	if err != nil {
+		return err
 	}
 
-	value, err := json.Marshal(event)
+	attempts, err := s.write(ctx, message)
+	if err == nil || ctx.Err() != nil {
+		return err
+	}
+	return s.deadLetter(ctx, event, message, attempts, err)
+}
+
+// PublishEventDirect publica o evento sem recorrer ao dead-letter: o erro da
+// última tentativa é devolvido a quem chamou.
+func (s *KafkaService) PublishEventDirect(ctx context.Context, event *models.UniversityEvent) error {
+	message, err := s.buildMessage(ctx, event)
 	if err != nil {
 		return err
 	}
 
-	return s.writer.WriteMessages(ctx, kafka.Message{
-		Value: value,
-	})
+	_, err = s.write(ctx, message)
+	return err
+}
+
+// write envia a mensagem ao tópico de eventos seguindo a política de retry e
+// retorna quantas tentativas foram feitas.
+func (s *KafkaService) write(ctx context.Context, message kafka.Message) (int, error) {
+	for attempt := 1; ; attempt++ {
+		err := s.writer.WriteMessages(ctx, message)
+		if err == nil || attempt >= s.retry.MaxAttempts {
+			return attempt, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return attempt, err
+		case <-time.After(s.retry.delay(attempt)):
+		}
+	}
+}
+
+func (s *KafkaService) buildMessage(ctx context.Context, event *models.UniversityEvent) (kafka.Message, error) {
+	prepareEvent(ctx, event)
+
+	value, headers, err := s.encode(event)
+	if err != nil {
+		return kafka.Message{}, err
+	}
+
+	headers = append(headers,
+		kafka.Header{Key: HeaderEventID, Value: []byte(event.ID)},
+		kafka.Header{Key: HeaderEventType, Value: []byte(event.Type)},
+		kafka.Header{Key: HeaderSchemaVersion, Value: []byte(strconv.Itoa(event.SchemaVersion))},
+		kafka.Header{Key: HeaderTimestamp, Value: []byte(event.OccurredAt.Format(time.RFC3339Nano))},
+		kafka.Header{Key: HeaderProducer, Value: []byte(s.producer)},
+	)
+	if event.CorrelationID != "" {
+		headers = append(headers, kafka.Header{Key: HeaderCorrelationID, Value: []byte(event.CorrelationID)})
+	}
+
+	return kafka.Message{
+		Key:     []byte(event.Key()),
+		Value:   value,
+		Headers: headers,
+		Time:    event.OccurredAt,
+	}, nil
+}
+
+func (s *KafkaService) encode(event *models.UniversityEvent) ([]byte, []kafka.Header, error) {
+	switch s.format {
+	case FormatCloudEventsStructured:
+		return encodeStructuredCloudEvent(event, s.source)
+	case FormatCloudEventsBinary:
+		return encodeBinaryCloudEvent(event, s.source)
+	case FormatAvro:
+		if s.avro == nil {
+			return nil, nil, errors.New("avro event format requires a schema registry")
+		}
+		value, err := s.avro.Encode(event)
+		headers := []kafka.Header{{Key: "content-type", Value: []byte(avroContentType)}}
+		return value, headers, err
+	default:
+		value, err := json.Marshal(event)
+		return value, nil, err
+	}
 }
 
 func (s *KafkaService) Close() error {
+	if s.deadLetters != nil {
+		if err := s.deadLetters.Close(); err != nil {
+			s.writer.Close()
+			return err
+		}
+	}
 	return s.writer.Close()
-}
\ No newline at end of file
+}
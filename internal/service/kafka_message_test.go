@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/university-service/internal/models"
+	"github.com/university-service/internal/schema"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestKafkaService_BuildMessage(t *testing.T) {
+	service := NewKafkaService([]string{"localhost:9092"}, "test_topic", WithProducerName("test-producer"))
+	defer service.Close()
+
+	uni := &models.University{ID: primitive.NewObjectID(), Name: "Test University"}
+	event := models.NewUniversityEvent(models.EventUniversityCreated, uni)
+	ctx := WithCorrelationID(context.Background(), "req-123")
+
+	msg, err := service.buildMessage(ctx, event)
+	assert.NoError(t, err)
+	assert.Equal(t, uni.ID.Hex(), string(msg.Key))
+
+	headers := map[string]string{}
+	for _, h := range msg.Headers {
+		headers[h.Key] = string(h.Value)
+	}
+	assert.Equal(t, event.ID, headers[HeaderEventID])
+	assert.Equal(t, models.EventUniversityCreated, headers[HeaderEventType])
+	assert.Equal(t, "2", headers[HeaderSchemaVersion])
+	assert.Equal(t, "req-123", headers[HeaderCorrelationID])
+	assert.Equal(t, "test-producer", headers[HeaderProducer])
+	assert.NotEmpty(t, headers[HeaderTimestamp])
+
+	var body models.UniversityEvent
+	assert.NoError(t, json.Unmarshal(msg.Value, &body))
+	assert.Equal(t, event.ID, body.ID)
+	assert.Equal(t, uni.ID, body.University.ID)
+}
+
+func TestKafkaService_BuildMessageCloudEvents(t *testing.T) {
+	uni := &models.University{ID: primitive.NewObjectID(), Name: "Test University"}
+
+	t.Run("Structured", func(t *testing.T) {
+		service := NewKafkaService([]string{"localhost:9092"}, "test_topic",
+			WithEventFormat(FormatCloudEventsStructured), WithCloudEventsSource("/test"))
+		defer service.Close()
+
+		event := models.NewUniversityEvent(models.EventUniversityCreated, uni)
+		msg, err := service.buildMessage(context.Background(), event)
+		assert.NoError(t, err)
+		assert.Equal(t, uni.ID.Hex(), string(msg.Key))
+
+		var body map[string]interface{}
+		assert.NoError(t, json.Unmarshal(msg.Value, &body))
+		assert.Equal(t, "1.0", body["specversion"])
+		assert.Equal(t, "com.university.created", body["type"])
+		assert.Equal(t, "/test", body["source"])
+		assert.Equal(t, event.ID, body["id"])
+		assert.Equal(t, uni.ID.Hex(), body["subject"])
+		assert.Equal(t, "application/json", body["datacontenttype"])
+		assert.NotEmpty(t, body["time"])
+		data := body["data"].(map[string]interface{})
+		assert.Equal(t, "Test University", data["university"].(map[string]interface{})["name"])
+	})
+
+	t.Run("Binary", func(t *testing.T) {
+		service := NewKafkaService([]string{"localhost:9092"}, "test_topic", WithEventFormat(FormatCloudEventsBinary))
+		defer service.Close()
+
+		event := models.NewUniversityEvent(models.EventUniversityPatched, uni)
+		event.ChangedFields = []string{"name"}
+		msg, err := service.buildMessage(context.Background(), event)
+		assert.NoError(t, err)
+
+		headers := map[string]string{}
+		for _, h := range msg.Headers {
+			headers[h.Key] = string(h.Value)
+		}
+		assert.Equal(t, "1.0", headers["ce_specversion"])
+		assert.Equal(t, "com.university.patched", headers["ce_type"])
+		assert.Equal(t, DefaultCloudEventsSource, headers["ce_source"])
+		assert.Equal(t, event.ID, headers["ce_id"])
+		assert.Equal(t, uni.ID.Hex(), headers["ce_subject"])
+		assert.Equal(t, "application/json", headers["content-type"])
+		assert.Equal(t, event.ID, headers[HeaderEventID])
+
+		var data cloudEventData
+		assert.NoError(t, json.Unmarshal(msg.Value, &data))
+		assert.Equal(t, uni.ID, data.University.ID)
+		assert.Equal(t, []string{"name"}, data.ChangedFields)
+	})
+}
+
+func TestParseEventFormat(t *testing.T) {
+	format, err := ParseEventFormat("")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatLegacy, format)
+
+	format, err = ParseEventFormat("cloudevents-binary")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatCloudEventsBinary, format)
+
+	_, err = ParseEventFormat("protobuf")
+	assert.Error(t, err)
+}
+
+func TestKafkaService_BuildMessageAvro(t *testing.T) {
+	ctx := context.Background()
+	registry, err := schema.NewFileRegistry(filepath.Join(t.TempDir(), "registry.json"))
+	assert.NoError(t, err)
+	codec, err := schema.NewAvroCodec(ctx, registry, "test_topic-value")
+	assert.NoError(t, err)
+
+	service := NewKafkaService([]string{"localhost:9092"}, "test_topic", WithEventFormat(FormatAvro), WithAvroCodec(codec))
+	defer service.Close()
+
+	uni := &models.University{ID: primitive.NewObjectID(), Name: "Test University", CreatedAt: time.Now()}
+	event := models.NewUniversityEvent(models.EventUniversityCreated, uni)
+
+	msg, err := service.buildMessage(ctx, event)
+	assert.NoError(t, err)
+	assert.Equal(t, uni.ID.Hex(), string(msg.Key))
+
+	decoded, err := codec.Decode(ctx, msg.Value)
+	assert.NoError(t, err)
+	assert.Equal(t, event.ID, decoded.ID)
+	assert.Equal(t, "Test University", decoded.University.Name)
+}
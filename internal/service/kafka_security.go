@@ -0,0 +1,143 @@
+package service
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+// KafkaSecurity reúne a configuração de conexão com clusters que exigem TLS
+// e/ou SASL (SASL_SSL). Com os dois campos nil, a conexão é PLAINTEXT.
+type KafkaSecurity struct {
+	TLS  *tls.Config
+	SASL sasl.Mechanism
+}
+
+func (s KafkaSecurity) transport() *kafka.Transport {
+	return &kafka.Transport{TLS: s.TLS, SASL: s.SASL}
+}
+
+func (s KafkaSecurity) dialer() *kafka.Dialer {
+	return &kafka.Dialer{
+		Timeout:       10 * time.Second,
+		DualStack:     true,
+		TLS:           s.TLS,
+		SASLMechanism: s.SASL,
+	}
+}
+
+// LoadTLSConfig monta a configuração TLS a partir dos arquivos PEM informados.
+// caFile é opcional (usa as CAs do sistema); certFile e keyFile, usados na
+// autenticação por certificado, devem ser informados juntos.
+func LoadTLSConfig(caFile, certFile, keyFile string, insecureSkipVerify bool) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: insecureSkipVerify,
+	}
+
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", caFile)
+		}
+		config.RootCAs = pool
+	}
+
+	if (certFile == "") != (keyFile == "") {
+		return nil, errors.New("kafka tls: cert_file and key_file must be set together")
+	}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+// NewSASLMechanism cria o mecanismo SASL: plain, scram-sha-256 ou
+// scram-sha-512. Retorna nil se mechanism for vazio.
+func NewSASLMechanism(mechanism, username, password string) (sasl.Mechanism, error) {
+	switch strings.ToLower(mechanism) {
+	case "":
+		return nil, nil
+	case "plain":
+		return plain.Mechanism{Username: username, Password: password}, nil
+	case "scram-sha-256":
+		return scram.Mechanism(scram.SHA256, username, password)
+	case "scram-sha-512":
+		return scram.Mechanism(scram.SHA512, username, password)
+	default:
+		return nil, fmt.Errorf("unknown sasl mechanism: %s", mechanism)
+	}
+}
+
+// ProducerSettings ajusta o envio das mensagens. Os campos zerados mantêm os
+// padrões do kafka-go.
+type ProducerSettings struct {
+	// none, gzip, snappy, lz4 ou zstd
+	Compression string
+	// none, leader ou all
+	RequiredAcks string
+	BatchSize    int
+	BatchTimeout time.Duration
+	// Com Async, PublishEvent retorna sem esperar a confirmação do broker e as
+	// falhas vão direto para as dead letters, sem novas tentativas
+	Async bool
+	// Cria o tópico no primeiro envio se ele não existir (exige
+	// auto.create.topics.enable no broker)
+	AutoCreateTopic bool
+}
+
+func parseCompression(value string) (kafka.Compression, error) {
+	switch strings.ToLower(value) {
+	case "", "none":
+		return 0, nil
+	case "gzip":
+		return kafka.Gzip, nil
+	case "snappy":
+		return kafka.Snappy, nil
+	case "lz4":
+		return kafka.Lz4, nil
+	case "zstd":
+		return kafka.Zstd, nil
+	default:
+		return 0, fmt.Errorf("unknown compression codec: %s", value)
+	}
+}
+
+func parseRequiredAcks(value string) (kafka.RequiredAcks, error) {
+	switch strings.ToLower(value) {
+	case "", "all":
+		return kafka.RequireAll, nil
+	case "leader", "one":
+		return kafka.RequireOne, nil
+	case "none":
+		return kafka.RequireNone, nil
+	default:
+		return 0, fmt.Errorf("unknown required acks: %s", value)
+	}
+}
+
+// Validate verifica os valores de compressão e confirmação.
+func (p ProducerSettings) Validate() error {
+	if _, err := parseCompression(p.Compression); err != nil {
+		return err
+	}
+	_, err := parseRequiredAcks(p.RequiredAcks)
+	return err
+}
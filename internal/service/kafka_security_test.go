@@ -0,0 +1,111 @@
+package service
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSASLMechanism(t *testing.T) {
+	mechanism, err := NewSASLMechanism("", "user", "secret")
+	assert.NoError(t, err)
+	assert.Nil(t, mechanism)
+
+	for name, expected := range map[string]string{
+		"plain":         "PLAIN",
+		"SCRAM-SHA-256": "SCRAM-SHA-256",
+		"scram-sha-512": "SCRAM-SHA-512",
+	} {
+		mechanism, err := NewSASLMechanism(name, "user", "secret")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, mechanism.Name())
+	}
+
+	_, err = NewSASLMechanism("gssapi", "user", "secret")
+	assert.Error(t, err)
+}
+
+func TestLoadTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCertificate(t, dir)
+
+	t.Run("CA And Client Certificate", func(t *testing.T) {
+		config, err := LoadTLSConfig(certFile, certFile, keyFile, false)
+		assert.NoError(t, err)
+		assert.NotNil(t, config.RootCAs)
+		assert.Len(t, config.Certificates, 1)
+	})
+
+	t.Run("Cert Without Key", func(t *testing.T) {
+		_, err := LoadTLSConfig("", certFile, "", false)
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid CA", func(t *testing.T) {
+		invalid := filepath.Join(dir, "invalid.pem")
+		assert.NoError(t, os.WriteFile(invalid, []byte("not a certificate"), 0o600))
+		_, err := LoadTLSConfig(invalid, "", "", false)
+		assert.Error(t, err)
+	})
+}
+
+func TestProducerSettings(t *testing.T) {
+	assert.NoError(t, ProducerSettings{}.Validate())
+	assert.NoError(t, ProducerSettings{Compression: "zstd", RequiredAcks: "leader"}.Validate())
+	assert.Error(t, ProducerSettings{Compression: "brotli"}.Validate())
+	assert.Error(t, ProducerSettings{RequiredAcks: "two"}.Validate())
+
+	service := NewKafkaService([]string{"localhost:9092"}, "test_topic", WithProducerSettings(ProducerSettings{
+		Compression:     "gzip",
+		RequiredAcks:    "leader",
+		BatchSize:       50,
+		BatchTimeout:    5 * time.Millisecond,
+		Async:           true,
+		AutoCreateTopic: true,
+	}))
+	defer service.Close()
+
+	assert.Equal(t, kafka.Gzip, service.writer.Compression)
+	assert.Equal(t, kafka.RequireOne, service.writer.RequiredAcks)
+	assert.Equal(t, 50, service.writer.BatchSize)
+	assert.Equal(t, 5*time.Millisecond, service.writer.BatchTimeout)
+	assert.True(t, service.writer.Async)
+	assert.True(t, service.writer.AllowAutoTopicCreation)
+}
+
+// writeTestCertificate grava um certificado autoassinado e sua chave em dir.
+func writeTestCertificate(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	assert.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/university-service/internal/models"
+)
+
+// NATSPublisher publica os eventos em um servidor NATS (ou compatível), no
+// subject <prefixo>.<tipo do evento>, por exemplo
+// university_events.university_created. O corpo é o JSON legacy e os
+// cabeçalhos são os mesmos das mensagens do Kafka.
+type NATSPublisher struct {
+	conn     *nats.Conn
+	subject  string
+	producer string
+}
+
+const natsFlushTimeout = 5 * time.Second
+
+func NewNATSPublisher(url, subject, producer string) (*NATSPublisher, error) {
+	if producer == "" {
+		producer = DefaultProducerName
+	}
+	conn, err := nats.Connect(url, nats.Name(producer))
+	if err != nil {
+		return nil, err
+	}
+	return &NATSPublisher{conn: conn, subject: subject, producer: producer}, nil
+}
+
+func (p *NATSPublisher) PublishEvent(ctx context.Context, event *models.UniversityEvent) error {
+	prepareEvent(ctx, event)
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	msg := nats.NewMsg(p.subject + "." + event.Type)
+	msg.Data = data
+	msg.Header.Set(HeaderEventID, event.ID)
+	msg.Header.Set(HeaderEventType, event.Type)
+	msg.Header.Set(HeaderSchemaVersion, strconv.Itoa(event.SchemaVersion))
+	msg.Header.Set(HeaderTimestamp, event.OccurredAt.Format(time.RFC3339Nano))
+	msg.Header.Set(HeaderProducer, p.producer)
+	if event.CorrelationID != "" {
+		msg.Header.Set(HeaderCorrelationID, event.CorrelationID)
+	}
+	// Chave de ordenação equivalente à chave da mensagem no Kafka
+	msg.Header.Set("university_id", event.Key())
+
+	if err := p.conn.PublishMsg(msg); err != nil {
+		return err
+	}
+	// Garante que o servidor recebeu a mensagem antes de confirmar a gravação.
+	// FlushWithContext exige um contexto com prazo, o que as requisições não têm
+	if _, ok := ctx.Deadline(); ok {
+		return p.conn.FlushWithContext(ctx)
+	}
+	return p.conn.FlushTimeout(natsFlushTimeout)
+}
+
+func (p *NATSPublisher) Close() error {
+	return p.conn.Drain()
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/university-service/internal/models"
+	"github.com/university-service/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type OutboxRelayConfig struct {
+	PollInterval time.Duration
+	BatchSize    int64
+	MaxAttempts  int
+	// Espera antes da primeira nova tentativa; dobra a cada falha até MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Tempo que um registro fica reservado para esta instância durante o envio
+	ClaimTimeout time.Duration
+}
+
+// OutboxRelay publica os eventos gravados na coleção outbox. Eventos
+// de uma mesma universidade são publicados na ordem em que foram gravados: se
+// um deles falhar, os seguintes aguardam a nova tentativa, e se ele esgotar as
+// tentativas (status failed), continuam bloqueados até que o registro seja
+// removido ou volte a pending.
+type OutboxRelay struct {
+	outbox *repository.OutboxRepository
+	// Publica sem o fallback de dead letters (veja DirectPublisher): uma falha
+	// precisa voltar ao relay para respeitar o backoff e a ordem dos eventos
+	publish func(ctx context.Context, event *models.UniversityEvent) error
+	cfg     OutboxRelayConfig
+}
+
+func NewOutboxRelay(outbox *repository.OutboxRepository, publisher EventPublisher, cfg OutboxRelayConfig) *OutboxRelay {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = time.Second
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 10
+	}
+	if cfg.Backoff <= 0 {
+		cfg.Backoff = time.Second
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = 5 * time.Minute
+	}
+	if cfg.ClaimTimeout <= 0 {
+		cfg.ClaimTimeout = 30 * time.Second
+	}
+
+	relay := &OutboxRelay{outbox: outbox, cfg: cfg}
+	if direct, ok := publisher.(DirectPublisher); ok {
+		relay.publish = direct.PublishEventDirect
+	} else if publisher != nil {
+		relay.publish = publisher.PublishEvent
+	}
+	return relay
+}
+
+// Run processa o outbox periodicamente até o contexto ser cancelado. Enquanto
+// houver entregas, os lotes seguem sem esperar o intervalo, já que cada lote
+// traz no máximo um evento por universidade.
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		delivered, err := r.ProcessBatch(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("outbox relay: %v", err)
+		}
+		if delivered > 0 && err == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessBatch tenta publicar um lote de registros prontos e retorna quantos
+// foram entregues. FetchPending só devolve o próximo evento de cada
+// universidade, então uma falha não afeta a ordem dos demais.
+func (r *OutboxRelay) ProcessBatch(ctx context.Context) (int, error) {
+	records, err := r.outbox.FetchPending(ctx, r.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, record := range records {
+		claimed, err := r.outbox.Claim(ctx, record.ID, time.Now().Add(r.cfg.ClaimTimeout))
+		if err != nil {
+			return delivered, err
+		}
+		if !claimed {
+			// Outra instância do relay está publicando o registro
+			continue
+		}
+
+		if err := r.publish(ctx, &record.Event); err != nil {
+			attempts := record.Attempts + 1
+			final := attempts >= r.cfg.MaxAttempts
+			if final {
+				log.Printf("outbox relay: giving up on record %s after %d attempts, later events of university %s stay blocked: %v",
+					record.ID.Hex(), attempts, eventUniversityID(&record.Event).Hex(), err)
+			}
+			if err := r.outbox.MarkFailed(ctx, record.ID, err, time.Now().Add(r.backoff(attempts)), final); err != nil {
+				return delivered, err
+			}
+			continue
+		}
+
+		if err := r.outbox.MarkDelivered(ctx, record.ID); err != nil {
+			return delivered, err
+		}
+		delivered++
+	}
+
+	return delivered, nil
+}
+
+func (r *OutboxRelay) backoff(attempts int) time.Duration {
+	wait := r.cfg.Backoff
+	for i := 1; i < attempts && wait < r.cfg.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > r.cfg.MaxBackoff {
+		wait = r.cfg.MaxBackoff
+	}
+	return wait
+}
+
+func eventUniversityID(event *models.UniversityEvent) primitive.ObjectID {
+	if event.University == nil {
+		return primitive.NilObjectID
+	}
+	return event.University.ID
+}
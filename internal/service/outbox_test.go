@@ -0,0 +1,21 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutboxRelay_Backoff(t *testing.T) {
+	relay := NewOutboxRelay(nil, nil, OutboxRelayConfig{
+		Backoff:    time.Second,
+		MaxBackoff: 10 * time.Second,
+	})
+
+	assert.Equal(t, time.Second, relay.backoff(1))
+	assert.Equal(t, 2*time.Second, relay.backoff(2))
+	assert.Equal(t, 8*time.Second, relay.backoff(4))
+	assert.Equal(t, 10*time.Second, relay.backoff(5))
+	assert.Equal(t, 10*time.Second, relay.backoff(50))
+}
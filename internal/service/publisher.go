@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/university-service/internal/models"
+)
+
+// EventPublisher publica os eventos das universidades. O backend é escolhido
+// em events.backend; veja as implementações abaixo e KafkaService.
+type EventPublisher interface {
+	PublishEvent(ctx context.Context, event *models.UniversityEvent) error
+	Close() error
+}
+
+// DeadLetterQueue é implementada pelos publishers que guardam os eventos que
+// não puderam ser publicados, como KafkaService.
+type DeadLetterQueue interface {
+	ListDeadLetters(ctx context.Context, limit int) ([]models.DeadLetter, error)
+	ReplayDeadLetters(ctx context.Context, limit int) (int, error)
+}
+
+// DirectPublisher é implementada pelos publishers com DeadLetterQueue.
+// PublishEventDirect devolve o erro de escrita em vez de guardar o evento como
+// dead letter, para quem tem sua própria política de novas tentativas e
+// precisa manter a ordem dos eventos, como OutboxRelay.
+type DirectPublisher interface {
+	PublishEventDirect(ctx context.Context, event *models.UniversityEvent) error
+}
+
+var (
+	_ EventPublisher  = (*KafkaService)(nil)
+	_ DeadLetterQueue = (*KafkaService)(nil)
+	_ DirectPublisher = (*KafkaService)(nil)
+	_ EventPublisher  = (*MemoryBus)(nil)
+	_ EventPublisher  = (*NATSPublisher)(nil)
+	_ EventPublisher  = (*FilePublisher)(nil)
+	_ EventPublisher  = NoopPublisher{}
+)
+
+// Backends de EventPublisher aceitos em events.backend
+const (
+	BackendKafka  = "kafka"
+	BackendMemory = "memory"
+	BackendNATS   = "nats"
+	BackendFile   = "file"
+	BackendNoop   = "noop"
+)
+
+// prepareEvent preenche os campos que podem faltar em eventos gravados no
+// outbox antes da existência deles.
+func prepareEvent(ctx context.Context, event *models.UniversityEvent) {
+	if event.ID == "" {
+		event.ID = uuid.NewString()
+	}
+	if event.SchemaVersion == 0 {
+		event.SchemaVersion = models.EventSchemaVersion
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+	if event.CorrelationID == "" {
+		event.CorrelationID = CorrelationID(ctx)
+	}
+}
+
+// EventHandler recebe os eventos publicados em um MemoryBus.
+type EventHandler func(ctx context.Context, event *models.UniversityEvent)
+
+// MemoryBus entrega os eventos aos assinantes no próprio processo, de forma
+// síncrona. Serve para testes e para instalações de um único nó.
+type MemoryBus struct {
+	mu          sync.RWMutex
+	subscribers map[int]EventHandler
+	next        int
+}
+
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{subscribers: map[int]EventHandler{}}
+}
+
+// Subscribe registra o handler e retorna a função que cancela a assinatura.
+func (b *MemoryBus) Subscribe(handler EventHandler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.next
+	b.next++
+	b.subscribers[id] = handler
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subscribers, id)
+	}
+}
+
+func (b *MemoryBus) PublishEvent(ctx context.Context, event *models.UniversityEvent) error {
+	prepareEvent(ctx, event)
+
+	b.mu.RLock()
+	handlers := make([]EventHandler, 0, len(b.subscribers))
+	for _, handler := range b.subscribers {
+		handlers = append(handlers, handler)
+	}
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(ctx, event)
+	}
+	return nil
+}
+
+func (b *MemoryBus) Close() error {
+	return nil
+}
+
+// NoopPublisher descarta os eventos.
+type NoopPublisher struct{}
+
+func (NoopPublisher) PublishEvent(ctx context.Context, event *models.UniversityEvent) error {
+	return nil
+}
+
+func (NoopPublisher) Close() error {
+	return nil
+}
+
+// FilePublisher grava os eventos em um arquivo, um JSON por linha (NDJSON),
+// no mesmo formato das mensagens legacy do Kafka.
+type FilePublisher struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewFilePublisher(path string) (*FilePublisher, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &FilePublisher{file: file}, nil
+}
+
+func (p *FilePublisher) PublishEvent(ctx context.Context, event *models.UniversityEvent) error {
+	prepareEvent(ctx, event)
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, err = p.file.Write(append(line, '\n'))
+	return err
+}
+
+func (p *FilePublisher) Close() error {
+	return p.file.Close()
+}
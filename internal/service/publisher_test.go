@@ -0,0 +1,59 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/university-service/internal/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMemoryBus(t *testing.T) {
+	bus := NewMemoryBus()
+	ctx := WithCorrelationID(context.Background(), "req-123")
+
+	var received []*models.UniversityEvent
+	unsubscribe := bus.Subscribe(func(ctx context.Context, event *models.UniversityEvent) {
+		received = append(received, event)
+	})
+
+	uni := &models.University{ID: primitive.NewObjectID(), Name: "Test University"}
+	assert.NoError(t, bus.PublishEvent(ctx, models.NewUniversityEvent(models.EventUniversityCreated, uni)))
+	assert.Len(t, received, 1)
+	assert.Equal(t, "req-123", received[0].CorrelationID)
+
+	unsubscribe()
+	assert.NoError(t, bus.PublishEvent(ctx, models.NewUniversityEvent(models.EventUniversityDeleted, uni)))
+	assert.Len(t, received, 1)
+}
+
+func TestFilePublisher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events", "events.ndjson")
+	publisher, err := NewFilePublisher(path)
+	assert.NoError(t, err)
+
+	uni := &models.University{ID: primitive.NewObjectID(), Name: "Test University"}
+	for _, eventType := range []string{models.EventUniversityCreated, models.EventUniversityUpdated} {
+		assert.NoError(t, publisher.PublishEvent(context.Background(), models.NewUniversityEvent(eventType, uni)))
+	}
+	assert.NoError(t, publisher.Close())
+
+	file, err := os.Open(path)
+	assert.NoError(t, err)
+	defer file.Close()
+
+	var types []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event models.UniversityEvent
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		assert.Equal(t, uni.ID, event.University.ID)
+		types = append(types, event.Type)
+	}
+	assert.Equal(t, []string{models.EventUniversityCreated, models.EventUniversityUpdated}, types)
+}
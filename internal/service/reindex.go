@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/university-service/internal/models"
+	"github.com/university-service/internal/repository"
+)
+
+// MaxReindexRate é o maior valor aceito em ReindexOptions.Rate. Taxas maiores
+// são reduzidas a ele; sem o limite, acima de 1e9 o intervalo entre os eventos
+// chegaria a zero, que time.NewTicker não aceita.
+const MaxReindexRate = 10000
+
+type ReindexOptions struct {
+	// Filtros das universidades republicadas; paginação e ordenação são ignoradas
+	Filter repository.ListOptions
+	// Máximo de eventos publicados por segundo, até MaxReindexRate; 0 para não
+	// limitar
+	Rate int
+}
+
+// Reindex publica um evento university_snapshot com o estado atual de cada
+// universidade que atende aos filtros e retorna quantos foram publicados. Os
+// eventos saem direto pelo publisher, sem passar pelo outbox, já que nenhuma
+// gravação é feita.
+func Reindex(ctx context.Context, repo repository.UniversityStore, publisher EventPublisher, opts ReindexOptions) (int, error) {
+	var throttle <-chan time.Time
+	if opts.Rate > 0 {
+		rate := min(opts.Rate, MaxReindexRate)
+		ticker := time.NewTicker(time.Second / time.Duration(rate))
+		defer ticker.Stop()
+		throttle = ticker.C
+	}
+
+	published := 0
+	err := repo.Each(ctx, opts.Filter, func(university *models.University) error {
+		if throttle != nil {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-throttle:
+			}
+		}
+
+		event := models.NewUniversityEvent(models.EventUniversitySnapshot, university)
+		event.CorrelationID = CorrelationID(ctx)
+		if err := publisher.PublishEvent(ctx, event); err != nil {
+			return err
+		}
+		published++
+		return nil
+	})
+	return published, err
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy define as tentativas de envio de uma mensagem ao Kafka antes de
+// ela ser desviada para as dead letters.
+type RetryPolicy struct {
+	MaxAttempts int
+	// Espera antes da segunda tentativa; dobra a cada falha até MaxBackoff
+	Backoff time.Duration
+	// Limite da espera; 0 para não limitar
+	MaxBackoff time.Duration
+	// Fração aleatória (0 a 1) somada ou subtraída da espera, para que várias
+	// instâncias não tentem de novo ao mesmo tempo
+	Jitter float64
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     100 * time.Millisecond,
+		MaxBackoff:  2 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+// delay retorna a espera após a tentativa de número attempt (a partir de 1).
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	wait := p.Backoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || wait < p.MaxBackoff); i++ {
+		if wait > math.MaxInt64/2 {
+			break
+		}
+		wait *= 2
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		wait += time.Duration((rand.Float64()*2 - 1) * p.Jitter * float64(wait))
+	}
+	return wait
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/university-service/internal/models"
+)
+
+// spoolFile guarda as dead letters em um arquivo local, um JSON por linha,
+// quando nem o tópico de dead letters está acessível.
+type spoolFile struct {
+	path string
+	mu   sync.Mutex
+}
+
+func (s *spoolFile) append(letter models.DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	line, err := json.Marshal(letter)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
+func (s *spoolFile) read() ([]models.DeadLetter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.readLocked()
+}
+
+func (s *spoolFile) readLocked() ([]models.DeadLetter, error) {
+	file, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var letters []models.DeadLetter
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var letter models.DeadLetter
+		if err := json.Unmarshal(scanner.Bytes(), &letter); err != nil {
+			return nil, err
+		}
+		letter.Source = models.DeadLetterSpool
+		letters = append(letters, letter)
+	}
+	return letters, scanner.Err()
+}
+
+// drain chama fn para cada dead letter do arquivo, na ordem em que foram
+// gravadas, até fn falhar. As que foram processadas com sucesso são removidas.
+func (s *spoolFile) drain(limit int, fn func(models.DeadLetter) error) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	letters, err := s.readLocked()
+	if err != nil || len(letters) == 0 {
+		return 0, err
+	}
+
+	done := 0
+	var fnErr error
+	for _, letter := range letters {
+		if limit > 0 && done >= limit {
+			break
+		}
+		if fnErr = fn(letter); fnErr != nil {
+			break
+		}
+		done++
+	}
+	if done == 0 {
+		return 0, fnErr
+	}
+
+	if err := s.rewriteLocked(letters[done:]); err != nil {
+		return done, err
+	}
+	return done, fnErr
+}
+
+func (s *spoolFile) rewriteLocked(letters []models.DeadLetter) error {
+	if len(letters) == 0 {
+		err := os.Remove(s.path)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	tmp := s.path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(file)
+	for _, letter := range letters {
+		line, err := json.Marshal(letter)
+		if err != nil {
+			file.Close()
+			return err
+		}
+		writer.Write(append(line, '\n'))
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
@@ -0,0 +1,86 @@
+package textutil
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Fold converte o texto para minúsculas e remove acentos, de forma que
+// "São Paulo" e "sao paulo" sejam considerados iguais.
+func Fold(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		b.WriteRune(foldRune(r))
+	}
+	return b.String()
+}
+
+// foldRune devolve sempre exatamente uma rune, o que mantém as posições do
+// texto original e do texto normalizado alinhadas.
+func foldRune(r rune) rune {
+	decomposed := []rune(norm.NFD.String(string(r)))
+	base := r
+	if len(decomposed) > 0 && !unicode.Is(unicode.Mn, decomposed[0]) {
+		base = decomposed[0]
+	}
+	return unicode.ToLower(base)
+}
+
+// Terms extrai os termos de uma consulta de busca textual, ignorando aspas e
+// termos negados com "-".
+func Terms(query string) []string {
+	var terms []string
+	for _, field := range strings.Fields(query) {
+		if strings.HasPrefix(field, "-") {
+			continue
+		}
+		field = strings.Trim(field, `"`)
+		if field != "" {
+			terms = append(terms, field)
+		}
+	}
+	return terms
+}
+
+// Highlight envolve com <em></em> as ocorrências dos termos no texto, sem
+// diferenciar maiúsculas nem acentos. Retorna o texto vazio se nenhum termo
+// for encontrado.
+func Highlight(text string, terms []string) string {
+	original := []rune(text)
+	folded := []rune(Fold(text))
+
+	marked := make([]bool, len(original))
+	found := false
+	for _, term := range terms {
+		needle := []rune(Fold(term))
+		if len(needle) == 0 {
+			continue
+		}
+		for i := 0; i+len(needle) <= len(folded); i++ {
+			if string(folded[i:i+len(needle)]) == string(needle) {
+				for j := i; j < i+len(needle); j++ {
+					marked[j] = true
+				}
+				found = true
+			}
+		}
+	}
+	if !found {
+		return ""
+	}
+
+	var b strings.Builder
+	for i, r := range original {
+		if marked[i] && (i == 0 || !marked[i-1]) {
+			b.WriteString("<em>")
+		}
+		b.WriteRune(r)
+		if marked[i] && (i == len(original)-1 || !marked[i+1]) {
+			b.WriteString("</em>")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,38 @@
+package textutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFold(t *testing.T) {
+	assert.Equal(t, "universidade de sao paulo", Fold("Universidade de São Paulo"))
+	assert.Equal(t, "pontificia universidade catolica", Fold("PONTIFÍCIA Universidade Católica"))
+	assert.Equal(t, "acao", Fold("Ação"))
+}
+
+func TestTerms(t *testing.T) {
+	assert.Equal(t, []string{"federal", "rio"}, Terms(`federal "rio" -paulo`))
+	assert.Empty(t, Terms("   "))
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		terms []string
+		want  string
+	}{
+		{"case and accent insensitive", "Universidade de São Paulo", []string{"sao"}, "Universidade de <em>São</em> Paulo"},
+		{"multiple terms", "Rua da Reitoria, 109", []string{"rua", "reitoria"}, "<em>Rua</em> da <em>Reitoria</em>, 109"},
+		{"adjacent matches are merged", "Campinas", []string{"camp", "inas"}, "<em>Campinas</em>"},
+		{"no match", "Universidade Federal", []string{"paulo"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Highlight(tt.text, tt.terms))
+		})
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"log"
+	"os"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -10,51 +11,122 @@ import (
 	"github.com/university-service/config"
 	"github.com/university-service/internal/repository"
 	"github.com/university-service/internal/service"
-	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func main() {
 	// Carregar configurações
 	cfg := config.LoadConfig()
 
-	// Conectar ao MongoDB
+	// compact precisa do arquivo fechado, por isso roda antes de abri-lo
+	if len(os.Args) > 1 && os.Args[1] == "compact" {
+		runCompact(cfg)
+		return
+	}
+
+	// Conectar ao armazenamento (storage.backend)
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.MongoDB.URI))
+	st, err := openStorage(ctx, cfg)
 	if err != nil {
 		log.Fatal(err)
 	}
-	defer client.Disconnect(ctx)
+	defer st.close()
+
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "consume":
+			if st.db == nil {
+				log.Fatal("consume requires the mongodb storage backend")
+			}
+			runConsumer(cfg, st.db)
+		case "reindex":
+			runReindex(cfg, st.store, os.Args[2:])
+		case "backup":
+			if err := runBackup(st.store, os.Args[2:]); err != nil {
+				log.Fatal(err)
+			}
+		default:
+			log.Fatalf("unknown command: %s", os.Args[1])
+		}
+		return
+	}
+
+	// Inicializar repositório. Índices e backfill podem demorar em coleções
+	// grandes, por isso não usam o timeout da conexão
+	if repo, ok := st.store.(*repository.UniversityRepository); ok {
+		if err := repo.EnsureIndexes(context.Background()); err != nil {
+			log.Fatal(err)
+		}
+		if err := repo.BackfillNormalizedNames(context.Background()); err != nil {
+			log.Fatal(err)
+		}
+	}
 
-	// Ping no MongoDB para verificar a conexão
-	err = client.Ping(ctx, nil)
+	// Inicializar o publisher de eventos (events.backend)
+	publisher, err := newEventPublisher(ctx, cfg)
 	if err != nil {
 		log.Fatal(err)
 	}
+	defer publisher.Close()
 
-	db := client.Database(cfg.MongoDB.Database)
-
-	// Inicializar repositório
-	repo := repository.NewUniversityRepository(db)
+	// Inicializar outbox e o relay que publica os eventos pendentes
+	var outbox *repository.OutboxRepository
+	if cfg.Outbox.Enabled && st.db == nil {
+		log.Printf("outbox disabled: it requires the mongodb storage backend")
+	}
+	if cfg.Outbox.Enabled && st.db != nil {
+		outbox = repository.NewOutboxRepository(st.db)
+		if err := outbox.CheckTransactions(ctx); err != nil {
+			log.Fatalf("outbox.enabled requires MongoDB transactions: %v (use a replica set, e.g. ?replicaSet=rs0 in the URI, or set outbox.enabled to false)", err)
+		}
+		if err := outbox.EnsureIndexes(context.Background(), cfg.Outbox.Retention); err != nil {
+			log.Fatal(err)
+		}
 
-	// Inicializar serviço Kafka
-	kafkaService := service.NewKafkaService(cfg.Kafka.Brokers, cfg.Kafka.Topic)
-	defer kafkaService.Close()
+		relay := service.NewOutboxRelay(outbox, publisher, service.OutboxRelayConfig{
+			PollInterval: cfg.Outbox.PollInterval,
+			BatchSize:    cfg.Outbox.BatchSize,
+			MaxAttempts:  cfg.Outbox.MaxAttempts,
+			Backoff:      cfg.Outbox.Backoff,
+			MaxBackoff:   cfg.Outbox.MaxBackoff,
+		})
+		relayCtx, stopRelay := context.WithCancel(context.Background())
+		defer stopRelay()
+		go relay.Run(relayCtx)
+	}
 
-	// Inicializar handler
-	handler := api.NewHandler(repo, kafkaService)
+	// Inicializar handlers
+	handler := api.NewHandler(st.store, publisher, outbox)
+	adminHandler := api.NewAdminHandler(st.store, publisher, outbox, cfg.SoftDelete.Retention)
 
 	// Configurar router
 	router := gin.Default()
+	router.Use(api.RequestID(), api.RequestActor())
 
 	// Rotas
 	router.POST("/universities", handler.CreateUniversity)
+	router.GET("/universities/search", handler.SearchUniversities)
+	router.GET("/universities/autocomplete", handler.AutocompleteUniversities)
 	router.GET("/universities/:id", handler.GetUniversity)
 	router.GET("/universities", handler.ListUniversities)
 	router.PUT("/universities/:id", handler.UpdateUniversity)
+	router.PATCH("/universities/:id", handler.PatchUniversity)
 	router.DELETE("/universities/:id", handler.DeleteUniversity)
+	router.POST("/universities/:id/restore", handler.RestoreUniversity)
+	router.GET("/universities/:id/revisions", handler.ListRevisions)
+	router.GET("/universities/:id/revisions/diff", handler.DiffRevisions)
+	router.GET("/universities/:id/revisions/:rev", handler.GetRevision)
+	router.POST("/universities/:id/revisions/:rev/revert", handler.RevertUniversity)
+
+	// Rotas administrativas
+	admin := router.Group("/admin", api.RequireAdminToken(cfg.Admin.Token))
+	admin.POST("/universities/purge", adminHandler.PurgeUniversities)
+	admin.POST("/universities/reindex", adminHandler.ReindexUniversities)
+	admin.GET("/universities/reindex", adminHandler.ReindexStatus)
+	admin.GET("/storage/backup", adminHandler.BackupStorage)
+	admin.GET("/events/dead-letters", adminHandler.ListDeadLetters)
+	admin.POST("/events/dead-letters/replay", adminHandler.ReplayDeadLetters)
 
 	// Iniciar servidor
 	if err := router.Run(cfg.Server.Port); err != nil {
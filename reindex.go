@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/university-service/config"
+	"github.com/university-service/internal/repository"
+	"github.com/university-service/internal/service"
+)
+
+// runReindex publica um evento university_snapshot para cada universidade que
+// atende aos filtros informados nos argumentos.
+func runReindex(cfg *config.Config, store repository.UniversityStore, args []string) {
+	flags := flag.NewFlagSet("reindex", flag.ExitOnError)
+	name := flags.String("name", "", "filtra pelo nome (parcial, sem diferenciar maiúsculas)")
+	emailDomain := flags.String("email-domain", "", "filtra pelo domínio do e-mail")
+	website := flags.String("website", "", "filtra pelo website (parcial)")
+	includeDeleted := flags.Bool("include-deleted", false, "inclui as universidades excluídas logicamente")
+	limit := flags.Int64("limit", 0, "número máximo de universidades (0 para todas)")
+	rate := flags.Int("rate", 0, fmt.Sprintf("eventos por segundo, até %d (0 para não limitar)", service.MaxReindexRate))
+	flags.Parse(args)
+	if *rate < 0 || *rate > service.MaxReindexRate {
+		log.Fatalf("reindex: rate must be between 0 and %d", service.MaxReindexRate)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	publisher, err := newEventPublisher(ctx, cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer publisher.Close()
+
+	published, err := service.Reindex(ctx, store, publisher, service.ReindexOptions{
+		Filter: repository.ListOptions{
+			Name:           *name,
+			EmailDomain:    *emailDomain,
+			Website:        *website,
+			IncludeDeleted: *includeDeleted,
+			Limit:          *limit,
+		},
+		Rate: *rate,
+	})
+	log.Printf("reindex: %d snapshot events published", published)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
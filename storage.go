@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/university-service/config"
+	"github.com/university-service/internal/repository"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// storage é o armazenamento escolhido em storage.backend.
+type storage struct {
+	store repository.UniversityStore
+	// Banco do MongoDB, nil nos demais backends. O outbox e a projeção de
+	// leitura dependem dele.
+	db    *mongo.Database
+	close func()
+}
+
+func openStorage(ctx context.Context, cfg *config.Config) (*storage, error) {
+	switch cfg.Storage.Backend {
+	case "", "mongodb":
+		client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.MongoDB.URI))
+		if err != nil {
+			return nil, err
+		}
+
+		// Ping no MongoDB para verificar a conexão
+		if err := client.Ping(ctx, nil); err != nil {
+			client.Disconnect(ctx)
+			return nil, err
+		}
+
+		db := client.Database(cfg.MongoDB.Database)
+		return &storage{
+			store: repository.NewUniversityRepository(db),
+			db:    db,
+			close: func() { client.Disconnect(context.Background()) },
+		}, nil
+	case "postgres":
+		poolConfig, err := pgxpool.ParseConfig(cfg.Postgres.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid postgres url: %w", err)
+		}
+		if cfg.Postgres.MaxConns > 0 {
+			poolConfig.MaxConns = cfg.Postgres.MaxConns
+		}
+		pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+		if err != nil {
+			return nil, err
+		}
+		if err := pool.Ping(ctx); err != nil {
+			pool.Close()
+			return nil, err
+		}
+
+		store := repository.NewPostgresStore(pool)
+		if err := store.Migrate(ctx); err != nil {
+			pool.Close()
+			return nil, err
+		}
+		return &storage{store: store, close: pool.Close}, nil
+	case "bolt":
+		if err := os.MkdirAll(cfg.Storage.DataDir, 0o755); err != nil {
+			return nil, err
+		}
+		store, err := repository.OpenBoltStore(boltPath(cfg))
+		if err != nil {
+			return nil, err
+		}
+		return &storage{store: store, close: func() { store.Close() }}, nil
+	case "memory":
+		return &storage{store: repository.NewMemoryStore(), close: func() {}}, nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend: %s", cfg.Storage.Backend)
+	}
+}
+
+// boltPath é o arquivo do backend bolt dentro de storage.data_dir.
+func boltPath(cfg *config.Config) string {
+	return filepath.Join(cfg.Storage.DataDir, "universities.db")
+}